@@ -4,21 +4,50 @@ package app
 
 import (
 	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/excel"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/fixture"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/leagues"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/plan"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/teams"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/venue"
 )
 
 // App represents the main application with all dependencies.
 // It serves as the central coordinator for the application, managing storage and providing access to handlers.
 type App struct {
-	storage *data.SessionData // In-memory storage for session data (arbiters, leagues, etc.)
+	storage   *data.SessionData    // In-memory storage for session data (arbiters, leagues, etc.)
+	schedules *excel.ScheduleCache // Downloaded chess-results schedules per tournament
+	rosters   *excel.RosterCache   // Downloaded team rosters per tournament
+	plans     *plan.Store          // Issued delegations and the schedule they were issued for, per league
+	venues    *venue.Store         // Playing hall addresses of home teams
+	aliases   *teams.AliasStore    // Confirmed chess-results team to chess.sk club pairings
+	custom    *leagues.Store       // Leagues registered here because the chess.sk API does not return them
+	fixtures  *fixture.Store       // Cup matches whose teams are not decided yet, per league
 }
 
 // New creates a new App instance with all dependencies initialized.
-// It sets up a new SessionData storage instance ready for use.
+// It sets up a new SessionData storage instance, the schedule and roster caches, the plan store, the venue directory, the team aliases and the placeholder fixtures ready for use.
+// Custom leagues are loaded into the storage right away, so they are available before the chess.sk data.
 // Returns a pointer to a new App instance.
 func New() *App {
-	return &App{
-		storage: data.NewSessionData(),
+	app := &App{
+		storage:   data.NewSessionData(),
+		schedules: excel.NewScheduleCache(excel.CacheDir, excel.CacheMaxAgeFromEnv()),
+		rosters:   excel.NewRosterCache(excel.CacheMaxAgeFromEnv()),
+		plans:     plan.NewStore(plan.DefaultDir),
+		venues:    venue.NewStore(venue.DefaultPath),
+		aliases:   teams.NewAliasStore(teams.DefaultAliasPath),
+		custom:    leagues.NewStore(leagues.DefaultPath),
+		fixtures:  fixture.NewStore(fixture.DefaultDir),
 	}
+
+	if custom, err := app.custom.Load(); err != nil {
+		logger.Error("Failed to load custom leagues: %v", err)
+	} else {
+		app.storage.SetCustomLeagues(custom)
+	}
+	return app
 }
 
 // GetStorage returns the storage instance for external access.
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/conflict"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/excel"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// errRostersUnavailable is returned by the conflict check when the delegated league's rosters cannot be loaded.
+var errRostersUnavailable = errors.New("team rosters are not available")
+
+// Response header telling the client whether delegateArbiters ran the conflict check,
+// with conflictCheckSkipped when it could not (the delegations are generated anyway).
+const (
+	conflictCheckHeader  = "X-Conflict-Check"
+	conflictCheckDone    = "checked"
+	conflictCheckSkipped = "skipped"
+)
+
+// conflictLeagues collects the schedules and rosters the conflict check is run against.
+// The delegated league's rosters are taken from the cache or downloaded (once per tournament at a time);
+// other leagues are only included when both their schedule and rosters have already been loaded.
+func (app *App) conflictLeagues(league *data.League) ([]conflict.League, error) {
+	tournamentID, err := excel.ExtractTournamentIDFromLeague(league)
+	if err != nil {
+		return nil, fmt.Errorf("league '%s': %w: %v", league.LeagueName, errRostersUnavailable, err)
+	}
+	rosters := app.rosters.Cached(tournamentID)
+	if rosters == nil {
+		if rosters, err = app.rosters.Get(tournamentID, false); err != nil {
+			return nil, fmt.Errorf("league '%s': %w: %v", league.LeagueName, errRostersUnavailable, err)
+		}
+	}
+	var rounds []data.Round
+	if schedule := app.schedules.Cached(tournamentID); schedule != nil {
+		rounds = schedule.Result.Rounds
+	}
+	leagues := []conflict.League{{
+		ID:      league.LeagueId,
+		Name:    league.LeagueName,
+		Rounds:  rounds,
+		Rosters: rosters.Result.Rosters,
+	}}
+
+	allLeagues, err := app.storage.GetAllLeagues()
+	if err != nil {
+		return leagues, nil
+	}
+	for i := range allLeagues {
+		other := &allLeagues[i]
+		if other.LeagueId == league.LeagueId {
+			continue
+		}
+		tournamentID, err := excel.ExtractTournamentIDFromLeague(other)
+		if err != nil {
+			continue
+		}
+		otherSchedule := app.schedules.Cached(tournamentID)
+		otherRosters := app.rosters.Cached(tournamentID)
+		if otherSchedule == nil || otherRosters == nil {
+			continue
+		}
+		leagues = append(leagues, conflict.League{
+			ID:      other.LeagueId,
+			Name:    other.LeagueName,
+			Rounds:  otherSchedule.Result.Rounds,
+			Rosters: otherRosters.Result.Rosters,
+		})
+	}
+	return leagues, nil
+}
+
+// arbiterIdentity returns the roster identity of a delegated arbiter.
+// The FIDE ID is taken from the loaded arbiters when the arbiter is known by player ID.
+func (app *App) arbiterIdentity(arbiter data.ArbiterData) conflict.Identity {
+	identity := conflict.Identity{
+		PlayerID: arbiter.PlayerID,
+		Name:     strings.TrimSpace(arbiter.FirstName + " " + arbiter.LastName),
+	}
+	if arbiter.PlayerID == "" {
+		return identity
+	}
+	if arbiters, err := app.storage.GetAllArbiters(); err == nil {
+		for _, known := range arbiters {
+			if known.PlayerId == arbiter.PlayerID {
+				identity.FideID = known.FideId
+				identity.ClubID = known.KlubId
+				identity.ClubName = known.KlubName
+				break
+			}
+		}
+	}
+	return identity
+}
+
+// findConflicts checks every delegated arbiter of a league against the team rosters,
+// including all arbiters of matches with several arbiters and all matches of venue groups.
+// Conflicts block the delegation, warnings (arbiters officiating their own club's match) are only reported.
+func (app *App) findConflicts(leagueID string, pdfDataArray []data.PDFData) ([]conflict.Conflict, []conflict.Conflict, error) {
+	league, err := app.leagueByStringID(leagueID)
+	if err != nil {
+		return nil, nil, err
+	}
+	leagues, err := app.conflictLeagues(league)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checker := conflict.NewChecker(leagues, conflict.DefaultMatchDuration, app.teamResolver().ClubID)
+	conflicts := []conflict.Conflict{}
+	warnings := []conflict.Conflict{}
+	for _, pdfData := range pdfDataArray {
+		for _, match := range pdfData.CoveredMatches() {
+			for _, official := range pdfData.MatchOfficials() {
+				if official.Arbiter.PlayerID == "" {
+					continue
+				}
+				identity := app.arbiterIdentity(official.Arbiter)
+				conflicts = append(conflicts, checker.Check(identity, league.LeagueId, match)...)
+				warnings = append(warnings, checker.CheckClub(identity, match)...)
+			}
+		}
+	}
+	return conflicts, warnings, nil
+}
+
+// checkArbiterConflicts reports delegations where the arbiter plays in the same league
+// or in a match at an overlapping time. It expects the same body as delegateArbiters and the "leagueId" query parameter.
+func (app *App) checkArbiterConflicts(c *gin.Context) {
+	var requestBody []data.PDFData
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	conflicts, warnings, err := app.findConflicts(c.Query("leagueId"), requestBody)
+	if errors.Is(err, errRostersUnavailable) {
+		logger.Error("Arbiter conflicts not checked: %v", err)
+		c.JSON(http.StatusOK, gin.H{"checked": false, "conflicts": []conflict.Conflict{}, "warnings": []conflict.Conflict{},
+			"warning": "Team rosters could not be loaded, conflicts were not checked: " + err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to check arbiter conflicts: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check arbiter conflicts: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"checked": true, "conflicts": conflicts, "warnings": warnings})
+}
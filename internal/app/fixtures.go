@@ -0,0 +1,114 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/excel"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/fixture"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// withFixtures returns a copy of rounds with the league's placeholder fixtures merged in.
+// Fixtures whose teams chess-results already lists keep the real team names; undecided pairing rows
+// of the export are only used for the pairings a fixture covers.
+func (app *App) withFixtures(league *data.League, rounds []data.Round, undecided []data.UndecidedPairing) []data.Round {
+	fixtures, err := app.fixtures.Load(league.LeagueId)
+	if err != nil {
+		logger.Error("Failed to load fixtures for league '%s': %v", league.LeagueName, err)
+		return rounds
+	}
+	if len(fixtures) == 0 {
+		return rounds
+	}
+
+	merged, decided := fixture.Apply(rounds, undecided, fixtures, excel.UploadTournamentID(league))
+	logger.Debug("Merged %d fixtures into league '%s', %d already have their teams", len(fixtures), league.LeagueName, decided)
+	return merged
+}
+
+// listFixtures returns the placeholder fixtures of a league.
+func (app *App) listFixtures(c *gin.Context) {
+	league, ok := app.fixtureLeague(c)
+	if !ok {
+		return
+	}
+
+	fixtures, err := app.fixtures.Load(league.LeagueId)
+	if err != nil {
+		logger.Error("Failed to load fixtures for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load fixtures: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"fixtures": fixtures})
+}
+
+// saveFixture adds a placeholder fixture to a league, or replaces the one with the same round and pairing.
+// The fixture shows up in /get-rounds like any other match and can be delegated right away.
+func (app *App) saveFixture(c *gin.Context) {
+	league, ok := app.fixtureLeague(c)
+	if !ok {
+		return
+	}
+
+	var requestBody fixture.Fixture
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	saved, err := app.fixtures.Save(league.LeagueId, requestBody)
+	if err != nil {
+		logger.Error("Failed to save fixture for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to save fixture: " + err.Error()})
+		return
+	}
+
+	logger.Info("Saved fixture R%d-%d (%s – %s) for league '%s'", saved.Round, saved.Pairing, saved.HomeLabel, saved.GuestLabel, league.LeagueName)
+	c.JSON(http.StatusOK, gin.H{"fixture": saved})
+}
+
+// deleteFixture removes a league's placeholder fixture identified by round and pairing.
+func (app *App) deleteFixture(c *gin.Context) {
+	league, ok := app.fixtureLeague(c)
+	if !ok {
+		return
+	}
+
+	round, roundErr := strconv.Atoi(c.Param("round"))
+	pairing, pairingErr := strconv.Atoi(c.Param("pairing"))
+	if roundErr != nil || pairingErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round or pairing"})
+		return
+	}
+
+	if err := app.fixtures.Remove(league.LeagueId, round, pairing); errors.Is(err, fixture.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		logger.Error("Failed to remove fixture R%d-%d of league '%s': %v", round, pairing, league.LeagueName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove fixture: " + err.Error()})
+		return
+	}
+
+	logger.Info("Removed fixture R%d-%d of league '%s'", round, pairing, league.LeagueName)
+	c.JSON(http.StatusOK, gin.H{"message": "Fixture removed"})
+}
+
+// fixtureLeague looks up the league from the "leagueId" path parameter, answering the request when it is unknown.
+func (app *App) fixtureLeague(c *gin.Context) (*data.League, bool) {
+	leagueID, err := strconv.Atoi(c.Param("leagueId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
+		return nil, false
+	}
+	league, err := app.storage.GetLeagueByID(leagueID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found: " + err.Error()})
+		return nil, false
+	}
+	return league, true
+}
@@ -1,10 +1,15 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
@@ -23,11 +28,36 @@ func (app *App) RegisterRoutes(r *gin.Engine) {
 	r.GET("/leagues", app.getLeagues)
 	r.GET("/arbiters/:id", app.getArbiterByID)
 	r.GET("/leagues/:id", app.getLeagueByID)
+	r.GET("/custom-leagues", app.listCustomLeagues)
+	r.POST("/custom-leagues", app.addCustomLeague)
+	r.DELETE("/custom-leagues/:id", app.deleteCustomLeague)
+	r.GET("/fixtures/:leagueId", app.listFixtures)
+	r.POST("/fixtures/:leagueId", app.saveFixture)
+	r.DELETE("/fixtures/:leagueId/:round/:pairing", app.deleteFixture)
+	r.GET("/clubs", app.getClubs)
+	r.GET("/clubs/:id", app.getClubByID)
 
 	r.POST("/prepare-pdf-data", app.preparePDFData)
 	r.POST("/download-excel", app.downloadExcel)
 	r.POST("/get-rounds", app.getRounds)
+	r.POST("/upload-rounds", app.uploadRounds)
+	r.POST("/get-rosters", app.getRosters)
+	r.GET("/schedule-cache", app.getScheduleCache)
+	r.POST("/schedule-changes", app.getScheduleChanges)
+	r.POST("/schedule-changes/accept", app.acceptScheduleChanges)
+	r.POST("/arbiter-conflicts", app.checkArbiterConflicts)
+	r.GET("/venues", app.listVenues)
+	r.POST("/venues", app.saveVenue)
+	r.PUT("/venues/:id", app.saveVenue)
+	r.DELETE("/venues/:id", app.deleteVenue)
+	r.POST("/venues/import", app.importVenues)
+	r.GET("/team-aliases", app.listTeamAliases)
+	r.POST("/team-aliases", app.confirmTeamAlias)
+	r.DELETE("/team-aliases", app.removeTeamAlias)
+	r.POST("/team-aliases/resolve", app.resolveTeams)
 	r.POST("/delegate-arbiters", app.delegateArbiters)
+	r.POST("/preview-delegation", app.previewDelegation)
+	r.POST("/delegate-tournament", app.delegateTournament)
 	r.POST("/load-external-data", app.loadExternalData)
 }
 
@@ -63,18 +93,27 @@ func (app *App) loadExternalData(c *gin.Context) {
 		return
 	}
 
-	logger.Info("External data loaded successfully - Arbiters: %v, Leagues: %v",
-		app.storage.HasData("arbiters"), app.storage.HasData("leagues"))
+	// Load clubs data - optional, team names fall back to the arbiters' clubs without it
+	clubsError := ""
+	if err := app.LoadClubs(); err != nil {
+		logger.Error("Failed to load clubs: %v", err)
+		clubsError = err.Error()
+	}
+
+	logger.Info("External data loaded successfully - Arbiters: %v, Leagues: %v, Clubs: %v",
+		app.storage.HasData("arbiters"), app.storage.HasData("leagues"), app.storage.HasData("clubs"))
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":         "External data loaded successfully",
 		"arbiters_loaded": app.storage.HasData("arbiters"),
 		"leagues_loaded":  app.storage.HasData("leagues"),
+		"clubs_loaded":    app.storage.HasData("clubs"),
+		"clubs_error":     clubsError,
 	})
 }
 
 // getExternalData returns raw external data by type from session storage.
-// It expects a URL parameter "type" (arbiters or leagues) and returns the raw data.
+// It expects a URL parameter "type" (arbiters, leagues or clubs) and returns the raw data.
 // This endpoint is useful for debugging and inspecting the loaded data structure.
 func (app *App) getExternalData(c *gin.Context) {
 	dataType := c.Param("type")
@@ -112,6 +151,28 @@ func (app *App) getLeagues(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"leagues": leagues})
 }
 
+// getClubs returns all clubs
+func (app *App) getClubs(c *gin.Context) {
+	clubs, err := app.storage.GetAllClubs()
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"clubs": clubs})
+}
+
+// getClubByID returns a specific club by its KlubId
+func (app *App) getClubByID(c *gin.Context) {
+	club, err := app.storage.GetClubByID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"club": club})
+}
+
 // getArbiterByID returns a specific arbiter by ID
 func (app *App) getArbiterByID(c *gin.Context) {
 	arbiterID := c.Param("id")
@@ -192,7 +253,8 @@ func (app *App) preparePDFData(c *gin.Context) {
 func (app *App) downloadExcel(c *gin.Context) {
 	// Parse request body to get league ID
 	var requestBody struct {
-		LeagueID int `json:"leagueId"`
+		LeagueID int  `json:"leagueId"`
+		Refresh  bool `json:"refresh"` // Bypass the schedule cache
 	}
 	if err := c.BindJSON(&requestBody); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
@@ -206,8 +268,8 @@ func (app *App) downloadExcel(c *gin.Context) {
 		return
 	}
 
-	// Download Excel file for the league
-	rounds, err := excel.ParseExcelForLeagueToRounds(league)
+	// Download Excel file for the league, unless a fresh copy is cached
+	schedule, err := app.schedules.ForLeague(league, requestBody.Refresh)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to download Excel file: " + err.Error()})
 		return
@@ -215,17 +277,25 @@ func (app *App) downloadExcel(c *gin.Context) {
 
 	// Return success response with rounds data
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Excel file downloaded successfully",
-		"rounds":  rounds,
-		"league":  league.LeagueName,
+		"message":   "Excel file downloaded successfully",
+		"rounds":    schedule.Result.Rounds,
+		"league":    league.LeagueName,
+		"fetchedAt": schedule.FetchedAt,
+		"fromCache": schedule.FromCache,
+		"stale":     schedule.Stale,
 	})
 }
 
-// getRounds gets rounds data for a specific league
+// getRounds gets rounds data for a specific league.
+// Matches played in the same hall at the same time are returned as venueGroups, so one arbiter can cover them.
+// The league's placeholder fixtures are merged in as matches with "placeholder" set until chess-results lists their teams.
+// Fully played rounds are left out unless includePlayed is set; their number is returned as playedRounds.
 func (app *App) getRounds(c *gin.Context) {
 	// Parse request body to get league ID
 	var requestBody struct {
-		LeagueID int `json:"leagueId"`
+		LeagueID      int  `json:"leagueId"`
+		Refresh       bool `json:"refresh"`       // Bypass the schedule cache
+		IncludePlayed bool `json:"includePlayed"` // Also return rounds where every match has a result
 	}
 
 	if err := c.BindJSON(&requestBody); err != nil {
@@ -244,28 +314,157 @@ func (app *App) getRounds(c *gin.Context) {
 		return
 	}
 
-	// Parse Excel file to get rounds
-	rounds, err := excel.ParseExcelForLeagueToRounds(league)
+	// Parse Excel file to get rounds, reusing a fresh cached download
+	schedule, err := app.schedules.ForLeague(league, requestBody.Refresh)
 	if err != nil {
 		logger.Error("Failed to parse rounds for league '%s': %v", league.LeagueName, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse rounds: " + err.Error()})
 		return
 	}
+	result := schedule.Result
+	allRounds := app.withVenues(league, app.withFixtures(league, result.Rounds, result.Undecided), result.Tournament.Location)
 
-	logger.Info("Successfully loaded %d rounds for league '%s'", len(rounds), league.LeagueName)
+	logger.Info("Successfully loaded %d rounds for league '%s' (layout: %s, language: %s, warnings: %d)",
+		len(result.Rounds), league.LeagueName, result.Dialect.Layout, result.Dialect.Language, len(result.Warnings))
 
 	// Store rounds in session data for later editing
-	app.storage.Set("current_rounds", rounds)
+	app.setCurrentRounds(league, allRounds)
+
+	// Compare with the schedule the league's delegations were issued for
+	changes, err := app.scheduleChanges(league.LeagueId, allRounds)
+	if err != nil {
+		logger.Error("Failed to compare schedule for league '%s': %v", league.LeagueName, err)
+	}
+
+	rounds, playedRounds := visibleRounds(allRounds, requestBody.IncludePlayed)
 
 	// Return rounds data
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Rounds data loaded successfully",
-		"rounds":  rounds,
-		"league":  league,
+		"message":      "Rounds data loaded successfully",
+		"rounds":       rounds,
+		"playedRounds": playedRounds,
+		"venueGroups":  data.GroupMatchesByVenue(rounds),
+		"dialect":      result.Dialect,
+		"warnings":     result.Warnings,
+		"tournament":   result.Tournament,
+		"league":       league,
+		"fetchedAt":    schedule.FetchedAt,
+		"fromCache":    schedule.FromCache,
+		"stale":        schedule.Stale,
+		"changes":      changes,
+	})
+}
+
+// visibleRounds drops the fully played rounds unless includePlayed is set.
+// Returns the rounds to show and the number of rounds that were left out.
+func visibleRounds(rounds []data.Round, includePlayed bool) ([]data.Round, int) {
+	if includePlayed {
+		return rounds, 0
+	}
+	unplayed := data.UnplayedRounds(rounds)
+	return unplayed, len(rounds) - len(unplayed)
+}
+
+// getScheduleCache lists when each cached chess-results schedule was last downloaded.
+func (app *App) getScheduleCache(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"maxAge":    app.schedules.MaxAge().String(),
+		"schedules": app.schedules.Status(),
+	})
+}
+
+// uploadRounds parses a chess-results schedule uploaded by the user instead of downloading it.
+// It expects a multipart form with "leagueId" and an .xlsx "file", and responds like getRounds.
+// Files that are not .xlsx workbooks (e.g. Excel 97-2003) are answered with 415 before anything is stored.
+// The optional "includePlayed" form value works like the getRounds option.
+func (app *App) uploadRounds(c *gin.Context) {
+	leagueID, err := strconv.Atoi(c.PostForm("leagueId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
+		return
+	}
+
+	league, err := app.storage.GetLeagueByID(leagueID)
+	if err != nil {
+		logger.Error("League not found (ID: %d): %v", leagueID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found: " + err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	if file.Size > excel.MaxUploadSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("File is too large (max %d MB)", excel.MaxUploadSize>>20)})
+		return
+	}
+	if err := excel.ValidateUploadFileName(file.Filename); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := checkUploadedWorkbook(file); err != nil {
+		logger.Info("Rejected uploaded schedule %s: %v", file.Filename, err)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	}
+
+	logger.Info("Parsing uploaded schedule %s for league '%s'", file.Filename, league.LeagueName)
+
+	if err := os.MkdirAll(excel.TempDir, 0755); err != nil {
+		logger.Error("Failed to create upload directory: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store uploaded file"})
+		return
+	}
+	filePath := filepath.Join(excel.TempDir, fmt.Sprintf("upload_%d_%d%s", leagueID, time.Now().UnixNano(), filepath.Ext(file.Filename)))
+	if err := c.SaveUploadedFile(file, filePath); err != nil {
+		logger.Error("Failed to save uploaded file %s: %v", file.Filename, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store uploaded file"})
+		return
+	}
+
+	result, err := excel.ParseUploadedExcel(filePath, file.Filename, excel.UploadTournamentID(league))
+	if errors.Is(err, excel.ErrUnsupportedWorkbook) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to parse uploaded schedule for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Failed to parse rounds: " + err.Error()})
+		return
+	}
+
+	allRounds := app.withVenues(league, app.withFixtures(league, result.Rounds, result.Undecided), result.Tournament.Location)
+
+	// Store rounds in session data for later editing
+	app.setCurrentRounds(league, allRounds)
+
+	// Compare with the schedule the league's delegations were issued for
+	changes, err := app.scheduleChanges(league.LeagueId, allRounds)
+	if err != nil {
+		logger.Error("Failed to compare schedule for league '%s': %v", league.LeagueName, err)
+	}
+
+	rounds, playedRounds := visibleRounds(allRounds, c.PostForm("includePlayed") == "true")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Rounds data loaded from uploaded file",
+		"rounds":       rounds,
+		"playedRounds": playedRounds,
+		"venueGroups":  data.GroupMatchesByVenue(rounds),
+		"dialect":      result.Dialect,
+		"warnings":     result.Warnings,
+		"tournament":   result.Tournament,
+		"league":       league,
+		"changes":      changes,
 	})
 }
 
-// delegateArbiters handles the main PDF generation for delegated arbiters
+// delegateArbiters handles the main PDF generation for delegated arbiters.
+// The optional "layout" query parameter (flat, round or arbiter) selects the folder structure of the ZIP package.
+// With the optional "leagueId" query parameter the delegations are recorded in the league's plan
+// and checked against the team rosters; conflicts are answered with 409 unless "allowConflicts" is "true".
 func (app *App) delegateArbiters(c *gin.Context) {
 	var requestBody []data.PDFData
 	if err := c.BindJSON(&requestBody); err != nil {
@@ -274,40 +473,118 @@ func (app *App) delegateArbiters(c *gin.Context) {
 		return
 	}
 
+	layout, err := pdf.ParseZipLayout(c.Query("layout"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Name every delegation that cannot be generated instead of failing on the first one
+	if invalid := pdf.ValidateBatch(requestBody); len(invalid) > 0 {
+		logger.Info("Refusing to generate delegations: %d invalid items", len(invalid))
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Some delegations cannot be generated", "invalidItems": invalid})
+		return
+	}
+
+	if leagueID := c.Query("leagueId"); leagueID != "" && c.Query("allowConflicts") != "true" {
+		conflicts, warnings, err := app.findConflicts(leagueID, requestBody)
+		if err != nil {
+			// Rosters are optional, an unreachable chess-results must not block the delegations,
+			// but the client is told that nobody was checked
+			logger.Error("Generating delegations for league %s without the arbiter conflict check: %v", leagueID, err)
+			c.Header(conflictCheckHeader, conflictCheckSkipped)
+		} else if len(conflicts) > 0 {
+			logger.Info("Refusing to generate delegations for league %s: %d arbiter conflicts", leagueID, len(conflicts))
+			c.JSON(http.StatusConflict, gin.H{"error": "Some arbiters play in this league", "conflicts": conflicts, "warnings": warnings})
+			return
+		} else {
+			for _, warning := range warnings {
+				logger.Info("Arbiter conflict warning for league %s: %s", leagueID, warning.Message)
+			}
+			c.Header(conflictCheckHeader, conflictCheckDone)
+		}
+	}
+
 	logger.Info("Generating PDFs for %d arbiters", len(requestBody))
 	logger.Debug("PDF generation data: %+v", requestBody)
 
-	// Generate PDFs
-	generatedFiles, err := pdf.GeneratePDFsFromDelegateArbiters(requestBody, "templates/delegacny_list_ligy.pdf")
+	template, err := pdf.LoadTemplateConfig(pdf.DefaultTemplatePath, pdf.DefaultTemplate)
 	if err != nil {
-		logger.Error("Failed to generate PDFs: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate PDFs: " + err.Error()})
+		logger.Error("Failed to load template configuration: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load template configuration: " + err.Error()})
 		return
 	}
 
-	// Create zip file with all generated PDFs
+	// Generate PDFs and package them together with the manifest
 	zipName := fmt.Sprintf("delegacne_listy_%d.zip", time.Now().Unix())
-	zipPath, err := pdf.CreateZipFromFiles(generatedFiles, zipName)
+	zipPath, err := pdf.GeneratePDFsAndZip(requestBody, template, zipName, layout)
 	if err != nil {
-		logger.Error("Failed to create zip file: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create zip file: " + err.Error()})
+		logger.Error("Failed to generate delegation package: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate delegation package: " + err.Error()})
 		return
 	}
 
-	// Clean up individual PDF files after creating zip
-	for _, file := range generatedFiles {
-		if err := os.Remove(file); err != nil {
-			logger.Error("Failed to remove temporary PDF file %s: %v", file, err)
+	logger.Info("Successfully generated delegation package: %s", zipName)
+
+	if leagueID := c.Query("leagueId"); leagueID != "" {
+		if err := app.recordDelegations(leagueID, requestBody, !template.RoleFields.HasRoleFields()); err != nil {
+			logger.Error("Failed to record delegations for league %s: %v", leagueID, err)
 		}
 	}
 
-	logger.Info("Successfully generated delegation package: %s", zipName)
-
 	// Return the zip file for download
 	c.Header("Content-Type", "application/zip")
 	c.FileAttachment(zipPath, zipName)
 }
 
+// previewDelegation renders a single delegation and returns it inline so it can be opened in a browser tab.
+// The preview is watermarked and generated in memory only, nothing is stored on the server.
+func (app *App) previewDelegation(c *gin.Context) {
+	var requestBody data.PDFData
+	if err := c.BindJSON(&requestBody); err != nil {
+		logger.Error("Failed to parse previewDelegation request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	// The preview must not render a delegation that could not be generated
+	if invalid := pdf.ValidateBatch([]data.PDFData{requestBody}); len(invalid) > 0 {
+		logger.Info("Refusing to preview an invalid delegation: %s", invalid[0].Error)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "The delegation cannot be generated", "invalidItems": invalid})
+		return
+	}
+
+	template, err := pdf.LoadTemplateConfig(pdf.DefaultTemplatePath, pdf.DefaultTemplate)
+	if err != nil {
+		logger.Error("Failed to load template configuration: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load template configuration: " + err.Error()})
+		return
+	}
+
+	content, fileName, err := pdf.GeneratePreviewPDF(requestBody, template)
+	if err != nil {
+		logger.Error("Failed to generate preview: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate preview: " + err.Error()})
+		return
+	}
+
+	logger.Info("Generated preview for %s vs %s", requestBody.Match.HomeTeam, requestBody.Match.GuestTeam)
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", fileName))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/pdf", content)
+}
+
+// checkUploadedWorkbook checks the content of an uploaded file with excel.CheckWorkbookHeader.
+func checkUploadedWorkbook(file *multipart.FileHeader) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read uploaded file: %v", err)
+	}
+	defer content.Close()
+	return excel.CheckWorkbookHeader(content)
+}
+
 // buildURLWithParams constructs a URL with query parameters from a base URL and parameter map.
 // It safely parses the base URL and adds the provided parameters as query strings.
 // Returns the constructed URL or the original base URL if parsing fails.
@@ -442,3 +719,43 @@ func (app *App) LoadLeagues(seasonStartYear string) error {
 
 	return nil
 }
+
+// ClubsURLEnv is the environment variable overriding DefaultClubsURL.
+const ClubsURLEnv = "CHESS_SK_CLUBS_URL"
+
+// DefaultClubsURL is the chess.sk club registry endpoint, next to the arbiters endpoint of the same API.
+const DefaultClubsURL = "https://chess.sk/api/matrika.php/v1/clubs"
+
+// LoadClubs loads clubs data from the chess.sk API and stores it in session storage.
+// Clubs are used to resolve team names to clubs. The registry is optional: the arbiters' clubs are used without it.
+// Returns an error if the API call fails.
+func (app *App) LoadClubs() error {
+	clubsURL := DefaultClubsURL
+	if env := os.Getenv(ClubsURLEnv); env != "" {
+		clubsURL = env
+	}
+
+	logger.Debug("Loading clubs from: %s", clubsURL)
+
+	if err := app.storage.LoadData("clubs", clubsURL); err != nil {
+		return fmt.Errorf("failed to load clubs: %v", err)
+	}
+
+	// The endpoint is configurable, so make sure it really returned a club list
+	rawData, _ := app.storage.Get("clubs")
+	missing, err := data.CheckClubsData(rawData)
+	if err != nil {
+		app.storage.Delete("clubs")
+		return fmt.Errorf("%s did not return a club list: %v", clubsURL, err)
+	}
+	if len(missing) > 0 {
+		logger.Error("Clubs from %s have no %s fields, they stay empty", clubsURL, strings.Join(missing, ", "))
+	}
+
+	// Log statistics about loaded clubs
+	if clubs, err := app.storage.GetAllClubs(); err == nil {
+		logger.Info("Loaded %d clubs", len(clubs))
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/leagues"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// listCustomLeagues returns the leagues registered in this application.
+func (app *App) listCustomLeagues(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"leagues": app.storage.GetCustomLeagues()})
+}
+
+// addCustomLeague registers a league that the chess.sk API does not return.
+// The league is listed by /leagues and works with /get-rounds and generation like any other league.
+func (app *App) addCustomLeague(c *gin.Context) {
+	var requestBody leagues.Request
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	league, err := app.custom.Add(requestBody)
+	if err != nil {
+		logger.Error("Failed to add custom league '%s': %v", requestBody.LeagueName, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to add league: " + err.Error()})
+		return
+	}
+	if err := app.reloadCustomLeagues(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	logger.Info("Added custom league '%s' (ID: %s, %s)", league.LeagueName, league.LeagueId, league.ChessResultsLink)
+	c.JSON(http.StatusOK, gin.H{"league": league})
+}
+
+// deleteCustomLeague removes a custom league. Leagues from the chess.sk API cannot be removed.
+func (app *App) deleteCustomLeague(c *gin.Context) {
+	leagueID := c.Param("id")
+	if err := app.custom.Remove(leagueID); errors.Is(err, leagues.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		logger.Error("Failed to remove custom league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove league: " + err.Error()})
+		return
+	}
+	if err := app.reloadCustomLeagues(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	logger.Info("Removed custom league %s", leagueID)
+	c.JSON(http.StatusOK, gin.H{"message": "League removed"})
+}
+
+// reloadCustomLeagues copies the stored custom leagues into the session storage.
+func (app *App) reloadCustomLeagues() error {
+	custom, err := app.custom.Load()
+	if err != nil {
+		logger.Error("Failed to load custom leagues: %v", err)
+		return err
+	}
+	app.storage.SetCustomLeagues(custom)
+	return nil
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/plan"
+	"github.com/gin-gonic/gin"
+)
+
+// setCurrentRounds stores the rounds being edited together with the league they belong to.
+func (app *App) setCurrentRounds(league *data.League, rounds []data.Round) {
+	app.storage.Set("current_rounds", rounds)
+	app.storage.Set("current_rounds_league", league.LeagueId)
+}
+
+// currentRoundsFor returns the rounds being edited if they belong to the league.
+func (app *App) currentRoundsFor(leagueID string) []data.Round {
+	owner, _ := app.storage.Get("current_rounds_league")
+	if owner != leagueID {
+		return nil
+	}
+	rounds, _ := app.storage.Get("current_rounds")
+	result, _ := rounds.([]data.Round)
+	return result
+}
+
+// recordDelegations adds generated delegations to the league's plan.
+// The first plan of a league also stores the schedule the delegations were issued for;
+// later schedule changes are compared against it until they are accepted.
+// letterPerOfficial tells whether the template gave every arbiter of a match a letter of their own.
+func (app *App) recordDelegations(leagueID string, pdfDataArray []data.PDFData, letterPerOfficial bool) error {
+	league, err := app.leagueByStringID(leagueID)
+	if err != nil {
+		return err
+	}
+
+	issuedAt := time.Now()
+	delegations := make([]plan.Delegation, 0, len(pdfDataArray))
+	for _, pdfData := range pdfDataArray {
+		delegations = append(delegations, plan.DelegationsFromPDFData(pdfData, issuedAt, letterPerOfficial)...)
+	}
+
+	// The plan is keyed by the league found, never by the ID from the request
+	_, err = app.plans.Update(league.LeagueId, func(p *plan.Plan) {
+		p.LeagueName = league.LeagueName
+		if len(p.Rounds) == 0 {
+			p.Rounds = app.currentRoundsFor(league.LeagueId)
+		}
+		p.Merge(delegations)
+	})
+	return err
+}
+
+// scheduleChanges compares rounds with the league's stored plan.
+// Returns nil when the league has no plan yet.
+func (app *App) scheduleChanges(leagueID string, rounds []data.Round) (*plan.ChangeReport, error) {
+	stored, err := app.plans.Load(leagueID)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	report := stored.CompareRounds(rounds)
+	return &report, nil
+}
+
+// leagueByStringID looks up a league by the string ID used in plans.
+// The whole value must be a number, so a request parameter such as "1/../x" is rejected.
+func (app *App) leagueByStringID(leagueID string) (*data.League, error) {
+	id, err := strconv.Atoi(leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid league ID: %q", leagueID)
+	}
+	return app.storage.GetLeagueByID(id)
+}
+
+// getScheduleChanges reports how the current chess-results schedule differs from the league's stored plan,
+// together with the delegations affected by each change.
+func (app *App) getScheduleChanges(c *gin.Context) {
+	var requestBody struct {
+		LeagueID int  `json:"leagueId"`
+		Refresh  bool `json:"refresh"` // Bypass the schedule cache
+	}
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	league, err := app.storage.GetLeagueByID(requestBody.LeagueID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found: " + err.Error()})
+		return
+	}
+
+	schedule, err := app.schedules.ForLeague(league, requestBody.Refresh)
+	if err != nil {
+		logger.Error("Failed to load schedule for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load schedule: " + err.Error()})
+		return
+	}
+
+	report, err := app.scheduleChanges(league.LeagueId, app.withVenues(league, app.withFixtures(league, schedule.Result.Rounds, schedule.Result.Undecided), schedule.Result.Tournament.Location))
+	if err != nil {
+		logger.Error("Failed to compare schedule for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare schedule: " + err.Error()})
+		return
+	}
+	if report == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No delegations have been issued for this league yet"})
+		return
+	}
+
+	logger.Info("Schedule of league '%s' has %d changes affecting %d delegations",
+		league.LeagueName, len(report.Changes), report.AffectedDelegations)
+
+	c.JSON(http.StatusOK, gin.H{
+		"changes":             report.Changes,
+		"affectedDelegations": report.AffectedDelegations,
+		"fetchedAt":           schedule.FetchedAt,
+	})
+}
+
+// acceptScheduleChanges makes the current schedule the league's planned schedule,
+// so the reported changes are not shown again.
+func (app *App) acceptScheduleChanges(c *gin.Context) {
+	var requestBody struct {
+		LeagueID int `json:"leagueId"`
+	}
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	league, err := app.storage.GetLeagueByID(requestBody.LeagueID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found: " + err.Error()})
+		return
+	}
+
+	// Prefer the rounds being edited, they may come from an uploaded file
+	rounds := app.currentRoundsFor(league.LeagueId)
+	if rounds == nil {
+		schedule, err := app.schedules.ForLeague(league, false)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load schedule: " + err.Error()})
+			return
+		}
+		rounds = app.withVenues(league, app.withFixtures(league, schedule.Result.Rounds, schedule.Result.Undecided), schedule.Result.Tournament.Location)
+	}
+
+	if _, err := app.plans.Update(league.LeagueId, func(p *plan.Plan) {
+		p.LeagueName = league.LeagueName
+		p.Rounds = rounds
+	}); err != nil {
+		logger.Error("Failed to update plan for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update plan: " + err.Error()})
+		return
+	}
+
+	logger.Info("Accepted current schedule for league '%s'", league.LeagueName)
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule changes accepted"})
+}
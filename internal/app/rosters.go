@@ -0,0 +1,54 @@
+package app
+
+import (
+	"net/http"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// getRosters returns the team rosters of a league's chess-results tournament.
+// Teams of the league's schedule without a roster are listed as unmatchedTeams.
+func (app *App) getRosters(c *gin.Context) {
+	var requestBody struct {
+		LeagueID int  `json:"leagueId"`
+		Refresh  bool `json:"refresh"` // Bypass the roster cache
+	}
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	league, err := app.storage.GetLeagueByID(requestBody.LeagueID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found: " + err.Error()})
+		return
+	}
+
+	rosters, err := app.rosters.ForLeague(league, requestBody.Refresh)
+	if err != nil {
+		logger.Error("Failed to load rosters for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load rosters: " + err.Error()})
+		return
+	}
+
+	// Link the rosters to the teams of the schedule; the rosters are still useful without it
+	unmatchedTeams := []string{}
+	if schedule, err := app.schedules.ForLeague(league, false); err != nil {
+		logger.Error("Failed to load schedule for league '%s': %v", league.LeagueName, err)
+	} else {
+		unmatchedTeams = rosters.Result.Rosters.UnmatchedTeams(schedule.Result.Rounds)
+	}
+
+	logger.Info("Loaded %d team rosters for league '%s' (%d schedule teams without roster)",
+		len(rosters.Result.Rosters), league.LeagueName, len(unmatchedTeams))
+
+	c.JSON(http.StatusOK, gin.H{
+		"rosters":        rosters.Result.Rosters,
+		"warnings":       rosters.Result.Warnings,
+		"unmatchedTeams": unmatchedTeams,
+		"fetchedAt":      rosters.FetchedAt,
+		"fromCache":      rosters.FromCache,
+		"stale":          rosters.Stale,
+	})
+}
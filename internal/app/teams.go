@@ -0,0 +1,148 @@
+package app
+
+import (
+	"net/http"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/teams"
+	"github.com/gin-gonic/gin"
+)
+
+// knownClubs returns the chess.sk clubs team names can be resolved to.
+// The club registry comes first; clubs of the loaded arbiters that are missing from it are added.
+func (app *App) knownClubs() []teams.Club {
+	clubs := []teams.Club{}
+	seen := make(map[string]bool)
+
+	if registry, err := app.storage.GetAllClubs(); err == nil {
+		for _, club := range registry {
+			if club.KlubId == "" || seen[club.KlubId] {
+				continue
+			}
+			seen[club.KlubId] = true
+			clubs = append(clubs, teams.Club{ID: club.KlubId, Name: club.KlubName})
+		}
+	}
+
+	arbiters, err := app.storage.GetAllArbiters()
+	if err != nil {
+		return clubs
+	}
+	for _, arbiter := range arbiters {
+		if arbiter.KlubId == "" || arbiter.KlubName == "" || seen[arbiter.KlubId] {
+			continue
+		}
+		seen[arbiter.KlubId] = true
+		clubs = append(clubs, teams.Club{ID: arbiter.KlubId, Name: arbiter.KlubName})
+	}
+	return clubs
+}
+
+// teamResolver returns a resolver with the confirmed aliases and the known clubs.
+func (app *App) teamResolver() *teams.Resolver {
+	aliases, err := app.aliases.Load()
+	if err != nil {
+		logger.Error("Failed to load team aliases: %v", err)
+	}
+	return teams.NewResolver(aliases, app.knownClubs())
+}
+
+// listTeamAliases returns the confirmed team aliases.
+func (app *App) listTeamAliases(c *gin.Context) {
+	aliases, err := app.aliases.Load()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load team aliases: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"aliases": aliases})
+}
+
+// resolveTeams resolves every team of a league's schedule to a chess.sk club,
+// with suggestions for the teams that still need to be confirmed.
+func (app *App) resolveTeams(c *gin.Context) {
+	var requestBody struct {
+		LeagueID int `json:"leagueId"`
+	}
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	league, err := app.storage.GetLeagueByID(requestBody.LeagueID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found: " + err.Error()})
+		return
+	}
+
+	schedule, err := app.schedules.ForLeague(league, false)
+	if err != nil {
+		logger.Error("Failed to load schedule for league '%s': %v", league.LeagueName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load schedule: " + err.Error()})
+		return
+	}
+
+	resolver := app.teamResolver()
+	resolutions := []teams.Resolution{}
+	seen := make(map[string]bool)
+	for _, round := range schedule.Result.Rounds {
+		for _, match := range round.Matches {
+			for _, team := range []string{match.HomeTeam, match.GuestTeam} {
+				if seen[team] {
+					continue
+				}
+				seen[team] = true
+				resolutions = append(resolutions, resolver.Resolve(team))
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"teams": resolutions})
+}
+
+// confirmTeamAlias stores the club a team belongs to, usually one of the suggestions of resolveTeams.
+func (app *App) confirmTeamAlias(c *gin.Context) {
+	var requestBody struct {
+		Team     string `json:"team"`
+		ClubID   string `json:"clubId"`
+		ClubName string `json:"clubName"` // Only needed for clubs that are not known yet
+	}
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	clubName := requestBody.ClubName
+	if club, ok := app.teamResolver().Club(requestBody.ClubID); ok {
+		clubName = club.Name
+	}
+	if clubName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown club ID: " + requestBody.ClubID})
+		return
+	}
+
+	alias, err := app.aliases.Confirm(teams.Alias{Team: requestBody.Team, ClubID: requestBody.ClubID, ClubName: clubName})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to confirm team alias: " + err.Error()})
+		return
+	}
+
+	logger.Info("Confirmed team '%s' as club '%s' (%s)", alias.Team, alias.ClubName, alias.ClubID)
+	c.JSON(http.StatusOK, alias)
+}
+
+// removeTeamAlias deletes the alias of the team given in the "team" query parameter.
+func (app *App) removeTeamAlias(c *gin.Context) {
+	team := c.Query("team")
+	removed, err := app.aliases.Remove(team)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove team alias: " + err.Error()})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No alias for team: " + team})
+		return
+	}
+
+	logger.Info("Removed alias of team '%s'", team)
+	c.JSON(http.StatusOK, gin.H{"message": "Team alias removed"})
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/pdf"
+	"github.com/gin-gonic/gin"
+)
+
+// delegateTournament generates the delegation of an individual tournament (e.g. a Slovak championship).
+// One document lists all delegated arbiters with their roles and is returned as a PDF download.
+func (app *App) delegateTournament(c *gin.Context) {
+	var requestBody data.TournamentPDFData
+	if err := c.BindJSON(&requestBody); err != nil {
+		logger.Error("Failed to parse delegateTournament request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if err := requestBody.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := pdf.LoadTemplateConfig(pdf.TournamentTemplatePath, pdf.DefaultTournamentTemplate)
+	if err != nil {
+		logger.Error("Failed to load tournament template configuration: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load template configuration: " + err.Error()})
+		return
+	}
+
+	outputPath, err := pdf.GenerateTournamentPDF(requestBody, template)
+	if err != nil {
+		logger.Error("Failed to generate tournament delegation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate tournament delegation: " + err.Error()})
+		return
+	}
+
+	logger.Info("Generated delegation for tournament '%s' (%d arbiters)", requestBody.Tournament.Name, len(requestBody.Officials))
+
+	c.Header("Content-Type", "application/pdf")
+	c.FileAttachment(outputPath, filepath.Base(outputPath))
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/excel"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/venue"
+	"github.com/gin-gonic/gin"
+)
+
+// withVenues returns a copy of rounds with missing match addresses filled from the venue directory.
+// Matches the directory has no venue for get the location from the export's tournament header, if any.
+// The parsed rounds are shared with the schedule cache and are never modified.
+func (app *App) withVenues(league *data.League, rounds []data.Round, tournamentLocation string) []data.Round {
+	filled := make([]data.Round, len(rounds))
+	for i, round := range rounds {
+		filled[i] = round
+		filled[i].Matches = append([]data.MatchInfo{}, round.Matches...)
+	}
+
+	directory, err := app.venues.Load()
+	if err != nil {
+		logger.Error("Failed to load venue directory: %v", err)
+	} else if count := directory.FillAddresses(filled, league.SaisonName, app.teamResolver().ClubName); count > 0 {
+		logger.Debug("Filled %d match addresses from the venue directory for league '%s'", count, league.LeagueName)
+	}
+
+	if tournamentLocation != "" {
+		for i := range filled {
+			for j := range filled[i].Matches {
+				if filled[i].Matches[j].Address == "" {
+					filled[i].Matches[j].Address = tournamentLocation
+				}
+			}
+		}
+	}
+	return filled
+}
+
+// listVenues returns the venue directory.
+func (app *App) listVenues(c *gin.Context) {
+	directory, err := app.venues.Load()
+	if err != nil {
+		logger.Error("Failed to load venue directory: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load venues: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"venues": directory.Venues})
+}
+
+// saveVenue adds a venue, or replaces it when the path has an ID.
+func (app *App) saveVenue(c *gin.Context) {
+	var requestBody venue.Venue
+	if err := c.BindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if idParam := c.Param("id"); idParam != "" {
+		id, err := strconv.Atoi(idParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid venue ID"})
+			return
+		}
+		requestBody.ID = id
+	} else {
+		requestBody.ID = 0
+	}
+
+	saved, err := app.venues.Save(requestBody)
+	if errors.Is(err, venue.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to save venue: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to save venue: " + err.Error()})
+		return
+	}
+
+	logger.Info("Saved venue %d for '%s'", saved.ID, saved.Team)
+	c.JSON(http.StatusOK, saved)
+}
+
+// deleteVenue removes a venue from the directory.
+func (app *App) deleteVenue(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid venue ID"})
+		return
+	}
+
+	if err := app.venues.Delete(id); errors.Is(err, venue.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		logger.Error("Failed to delete venue %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete venue: " + err.Error()})
+		return
+	}
+
+	logger.Info("Deleted venue %d", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Venue deleted"})
+}
+
+// importVenues adds the venues of an uploaded .xlsx file (multipart "file") to the directory.
+func (app *App) importVenues(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	if file.Size > excel.MaxUploadSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("File is too large (max %d MB)", excel.MaxUploadSize>>20)})
+		return
+	}
+	if !strings.EqualFold(filepath.Ext(file.Filename), ".xlsx") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only .xlsx files can be imported"})
+		return
+	}
+
+	if err := os.MkdirAll(excel.TempDir, 0755); err != nil {
+		logger.Error("Failed to create upload directory: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store uploaded file"})
+		return
+	}
+	filePath := filepath.Join(excel.TempDir, fmt.Sprintf("venues_%d.xlsx", time.Now().UnixNano()))
+	if err := c.SaveUploadedFile(file, filePath); err != nil {
+		logger.Error("Failed to save uploaded file %s: %v", file.Filename, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store uploaded file"})
+		return
+	}
+	defer excel.CleanupTempFile(filePath)
+
+	result, err := app.venues.ImportFile(filePath)
+	if err != nil {
+		logger.Error("Failed to import venues from %s: %v", file.Filename, err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Failed to import venues: " + err.Error()})
+		return
+	}
+
+	logger.Info("Imported %d venues from %s (%d rows skipped)", result.Imported, file.Filename, len(result.SkippedRows))
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,204 @@
+// Package conflict detects delegations where the arbiter is also a player.
+// An arbiter registered in a team roster must not officiate in the league the team plays in,
+// nor a match that overlaps with one of the team's own matches in any other league.
+// Membership in the club of one of the teams is only reported as a warning (see CheckClub).
+package conflict
+
+import (
+	"fmt"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// Conflict types reported in Conflict.Type.
+const (
+	ConflictOwnMatch   = "own_match"   // The arbiter plays for one of the two teams of the match
+	ConflictSameLeague = "same_league" // The arbiter plays for another team of the same league
+	ConflictOverlap    = "overlap"     // The arbiter's team plays a match at an overlapping time
+	ConflictOwnClub    = "own_club"    // The arbiter is a member of the club of one of the teams (a warning, see CheckClub)
+)
+
+// DefaultMatchDuration is how long a team match is assumed to take when checking for overlaps.
+const DefaultMatchDuration = 6 * time.Hour
+
+// Identity identifies an arbiter in the team rosters.
+type Identity struct {
+	PlayerID string // National player ID (Arbiter.PlayerId)
+	FideID   string // FIDE ID (Arbiter.FideId), optional
+	ClubID   string // chess.sk club ID (Arbiter.KlubId), optional
+	ClubName string // Club name used in explanations
+	Name     string // Display name used in explanations
+}
+
+// League holds the schedule and team rosters of one league.
+type League struct {
+	ID      string       // League ID
+	Name    string       // League name used in explanations
+	Rounds  []data.Round // Parsed schedule
+	Rosters data.Rosters // Team rosters of the league's tournament
+}
+
+// Conflict describes why an arbiter should not be delegated to a match.
+type Conflict struct {
+	Type         string          `json:"type"`                   // One of the Conflict* types
+	MatchID      string          `json:"matchId"`                // Match the arbiter was assigned to
+	Round        int             `json:"round"`                  // Round of the assigned match
+	HomeTeam     string          `json:"homeTeam"`               // Home team of the assigned match
+	GuestTeam    string          `json:"guestTeam"`              // Guest team of the assigned match
+	Arbiter      string          `json:"arbiter"`                // Arbiter name
+	Team         string          `json:"team"`                   // Team the arbiter plays for (or whose club they belong to)
+	League       string          `json:"league"`                 // League of that team
+	Board        int             `json:"board"`                  // Arbiter's board in the team roster, 0 for club conflicts
+	PlayingMatch *data.MatchInfo `json:"playingMatch,omitempty"` // The team's own match overlapping the assignment
+	Message      string          `json:"message"`                // Human readable explanation
+}
+
+// Checker checks assignments against the rosters of all known leagues.
+type Checker struct {
+	leagues       []League
+	matchDuration time.Duration
+	clubOf        func(team string) string
+}
+
+// NewChecker creates a checker for the given leagues, assuming matches last matchDuration.
+// clubOf returns the chess.sk club ID of a team ("" when unknown); it may be nil to skip CheckClub.
+func NewChecker(leagues []League, matchDuration time.Duration, clubOf func(team string) string) *Checker {
+	return &Checker{leagues: leagues, matchDuration: matchDuration, clubOf: clubOf}
+}
+
+// Check returns the conflicts of delegating the arbiter to a match of the league with the given ID.
+func (c *Checker) Check(arbiter Identity, leagueID string, match data.MatchData) []Conflict {
+	conflicts := []Conflict{}
+	startsAt, err := data.ParseDateTime(match.DateTime)
+	hasStart := err == nil
+
+	for _, league := range c.leagues {
+		for _, roster := range league.Rosters {
+			player := findPlayer(roster, arbiter)
+			if player == nil {
+				continue
+			}
+
+			newConflict := func(conflictType, message string) Conflict {
+				return Conflict{
+					Type:      conflictType,
+					MatchID:   match.MatchID,
+					Round:     match.Round,
+					HomeTeam:  match.HomeTeam,
+					GuestTeam: match.GuestTeam,
+					Arbiter:   arbiter.Name,
+					Team:      roster.Team,
+					League:    league.Name,
+					Board:     player.Board,
+					Message:   message,
+				}
+			}
+
+			if league.ID == leagueID {
+				if isTeam(roster.Team, match.HomeTeam) || isTeam(roster.Team, match.GuestTeam) {
+					// The team's overlapping match is this very match, so there is nothing to add
+					conflicts = append(conflicts, newConflict(ConflictOwnMatch,
+						fmt.Sprintf("%s plays for %s (board %d), one of the teams of this match", arbiter.Name, roster.Team, player.Board)))
+					continue
+				}
+				conflict := newConflict(ConflictSameLeague,
+					fmt.Sprintf("%s plays for %s (board %d) in the same league", arbiter.Name, roster.Team, player.Board))
+				if hasStart {
+					if own := c.teamMatchesAround(league, roster.Team, startsAt, match); len(own) > 0 {
+						conflict.PlayingMatch = &own[0]
+						conflict.Message += fmt.Sprintf(": %s - %s at %s", own[0].HomeTeam, own[0].GuestTeam, own[0].DateTime)
+					}
+				}
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+
+			if !hasStart {
+				continue
+			}
+			for _, own := range c.teamMatchesAround(league, roster.Team, startsAt, match) {
+				conflict := newConflict(ConflictOverlap,
+					fmt.Sprintf("%s plays for %s (board %d) in %s: %s - %s at %s",
+						arbiter.Name, roster.Team, player.Board, league.Name, own.HomeTeam, own.GuestTeam, own.DateTime))
+				conflict.PlayingMatch = &own
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+	return conflicts
+}
+
+// CheckClub reports the teams of the match that belong to the arbiter's own club.
+// Club members often officiate their club's matches when nobody else is available, so these are warnings
+// that never block a delegation on their own.
+func (c *Checker) CheckClub(arbiter Identity, match data.MatchData) []Conflict {
+	conflicts := []Conflict{}
+	if c.clubOf == nil || arbiter.ClubID == "" {
+		return conflicts
+	}
+	for _, team := range []string{match.HomeTeam, match.GuestTeam} {
+		if c.clubOf(team) != arbiter.ClubID {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			Type:      ConflictOwnClub,
+			MatchID:   match.MatchID,
+			Round:     match.Round,
+			HomeTeam:  match.HomeTeam,
+			GuestTeam: match.GuestTeam,
+			Arbiter:   arbiter.Name,
+			Team:      team,
+			Message:   fmt.Sprintf("%s is a member of %s, the club of %s", arbiter.Name, arbiter.ClubName, team),
+		})
+	}
+	return conflicts
+}
+
+// teamMatchesAround returns the team's matches in the league that overlap a match starting at startsAt,
+// leaving out the assigned match itself.
+func (c *Checker) teamMatchesAround(league League, team string, startsAt time.Time, assigned data.MatchData) []data.MatchInfo {
+	var matches []data.MatchInfo
+	for _, round := range league.Rounds {
+		for _, match := range round.Matches {
+			if match.StartsAt == nil || !(isTeam(team, match.HomeTeam) || isTeam(team, match.GuestTeam)) {
+				continue
+			}
+			if isSameMatch(round.Number, match, assigned) {
+				continue
+			}
+			gap := match.StartsAt.Sub(startsAt)
+			if gap < c.matchDuration && gap > -c.matchDuration {
+				matches = append(matches, match)
+			}
+		}
+	}
+	return matches
+}
+
+// isSameMatch reports whether a schedule match is the assigned match.
+// Matches are compared by ID, or by round and teams when either of them has no ID.
+func isSameMatch(round int, match data.MatchInfo, assigned data.MatchData) bool {
+	if match.ID != "" && assigned.MatchID != "" {
+		return match.ID == assigned.MatchID
+	}
+	return round == assigned.Round && isTeam(match.HomeTeam, assigned.HomeTeam) && isTeam(match.GuestTeam, assigned.GuestTeam)
+}
+
+// findPlayer returns the roster entry of the arbiter, matched by national or FIDE ID.
+func findPlayer(roster data.TeamRoster, arbiter Identity) *data.RosterPlayer {
+	for i, player := range roster.Players {
+		if arbiter.PlayerID != "" && player.PlayerID == arbiter.PlayerID {
+			return &roster.Players[i]
+		}
+		if arbiter.FideID != "" && arbiter.FideID != "0" && player.FideID == arbiter.FideID {
+			return &roster.Players[i]
+		}
+	}
+	return nil
+}
+
+// isTeam reports whether two team names refer to the same team.
+func isTeam(a, b string) bool {
+	return data.NormalizeTeamName(a) == data.NormalizeTeamName(b)
+}
@@ -0,0 +1,237 @@
+package conflict
+
+import (
+	"reflect"
+	"testing"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// conflictSummary is the part of a conflict the tests compare.
+type conflictSummary struct {
+	Type         string
+	Team         string
+	League       string
+	Board        int
+	PlayingMatch string // ID of the overlapping match, empty when none
+}
+
+// summarize reduces conflicts to comparable summaries.
+func summarize(conflicts []Conflict) []conflictSummary {
+	summaries := []conflictSummary{}
+	for _, conflict := range conflicts {
+		summary := conflictSummary{Type: conflict.Type, Team: conflict.Team, League: conflict.League, Board: conflict.Board}
+		if conflict.PlayingMatch != nil {
+			summary.PlayingMatch = conflict.PlayingMatch.ID
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// scheduled builds a schedule match with its parsed start.
+func scheduled(t *testing.T, id, home, guest, dateTime string) data.MatchInfo {
+	t.Helper()
+	startsAt, err := data.ParseDateTime(dateTime)
+	if err != nil {
+		t.Fatalf("invalid test date %q: %v", dateTime, err)
+	}
+	return data.MatchInfo{ID: id, HomeTeam: home, GuestTeam: guest, DateTime: dateTime, StartsAt: &startsAt}
+}
+
+// testLeagues returns two leagues whose teams share players with the arbiters of the tests.
+func testLeagues(t *testing.T) []League {
+	t.Helper()
+	return []League{
+		{
+			ID:   "1",
+			Name: "1. liga",
+			Rounds: []data.Round{{Number: 1, Matches: []data.MatchInfo{
+				scheduled(t, "a-1-1", "ŠK Prievidza", "ŠKŠ Dubnica", "2025/10/25 11:00"),
+				scheduled(t, "a-1-2", "TJ Slávia", "ŠK Modra", "2025/10/25 11:00"),
+			}}},
+			Rosters: data.Rosters{
+				{Team: "ŠK Prievidza", StartingRank: 1, Players: []data.RosterPlayer{{Board: 3, Name: "Novák, Ján", PlayerID: "111"}}},
+				{Team: "TJ Slávia", StartingRank: 2, Players: []data.RosterPlayer{{Board: 1, Name: "Kráľ, Ivan", FideID: "999"}}},
+			},
+		},
+		{
+			ID:   "2",
+			Name: "2. liga",
+			Rounds: []data.Round{
+				{Number: 1, Matches: []data.MatchInfo{
+					scheduled(t, "b-1-1", "ŠK Prievidza B", "ŠK Nitra", "2025/10/25 14:00"),
+				}},
+				{Number: 2, Matches: []data.MatchInfo{
+					scheduled(t, "b-2-1", "ŠK Nitra", "ŠK Prievidza B", "2025/11/15 10:00"),
+				}},
+			},
+			Rosters: data.Rosters{
+				{Team: "ŠK Prievidza B", StartingRank: 1, Players: []data.RosterPlayer{{Board: 2, Name: "Kováč, Peter", PlayerID: "222"}}},
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	checker := NewChecker(testLeagues(t), DefaultMatchDuration, nil)
+
+	ownMatch := data.MatchData{MatchID: "a-1-1", Round: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", DateTime: "2025/10/25 11:00"}
+	otherMatch := data.MatchData{MatchID: "a-1-2", Round: 1, HomeTeam: "TJ Slávia", GuestTeam: "ŠK Modra", DateTime: "2025/10/25 11:00"}
+
+	tests := []struct {
+		name     string
+		arbiter  Identity
+		leagueID string
+		match    data.MatchData
+		want     []conflictSummary
+	}{
+		{
+			name:     "arbiter in no roster",
+			arbiter:  Identity{PlayerID: "555", Name: "Horváth"},
+			leagueID: "1",
+			match:    ownMatch,
+			want:     []conflictSummary{},
+		},
+		{
+			name:     "arbiter plays for one of the teams",
+			arbiter:  Identity{PlayerID: "111", Name: "Novák"},
+			leagueID: "1",
+			match:    ownMatch,
+			want:     []conflictSummary{{Type: ConflictOwnMatch, Team: "ŠK Prievidza", League: "1. liga", Board: 3}},
+		},
+		{
+			name:     "arbiter plays in the same league at the same time",
+			arbiter:  Identity{PlayerID: "111", Name: "Novák"},
+			leagueID: "1",
+			match:    otherMatch,
+			want: []conflictSummary{
+				{Type: ConflictSameLeague, Team: "ŠK Prievidza", League: "1. liga", Board: 3, PlayingMatch: "a-1-1"},
+			},
+		},
+		{
+			name:     "arbiter found by FIDE ID",
+			arbiter:  Identity{FideID: "999", Name: "Kráľ"},
+			leagueID: "1",
+			match:    ownMatch,
+			want: []conflictSummary{
+				{Type: ConflictSameLeague, Team: "TJ Slávia", League: "1. liga", Board: 1, PlayingMatch: "a-1-2"},
+			},
+		},
+		{
+			name:     "same league without a start time",
+			arbiter:  Identity{PlayerID: "111", Name: "Novák"},
+			leagueID: "1",
+			match:    data.MatchData{Round: 1, HomeTeam: "TJ Slávia", GuestTeam: "ŠK Modra", DateTime: "podľa dohody", FreeDateTime: true},
+			want:     []conflictSummary{{Type: ConflictSameLeague, Team: "ŠK Prievidza", League: "1. liga", Board: 3}},
+		},
+		{
+			name:     "overlapping match in another league",
+			arbiter:  Identity{PlayerID: "222", Name: "Kováč"},
+			leagueID: "1",
+			match:    otherMatch,
+			want: []conflictSummary{
+				{Type: ConflictOverlap, Team: "ŠK Prievidza B", League: "2. liga", Board: 2, PlayingMatch: "b-1-1"},
+			},
+		},
+		{
+			name:     "no overlap in another league",
+			arbiter:  Identity{PlayerID: "222", Name: "Kováč"},
+			leagueID: "1",
+			match:    data.MatchData{Round: 2, HomeTeam: "TJ Slávia", GuestTeam: "ŠK Modra", DateTime: "2025/11/15 17:00"},
+			want:     []conflictSummary{},
+		},
+		{
+			name:     "another league without a start time",
+			arbiter:  Identity{PlayerID: "222", Name: "Kováč"},
+			leagueID: "1",
+			match:    data.MatchData{Round: 1, HomeTeam: "TJ Slávia", GuestTeam: "ŠK Modra"},
+			want:     []conflictSummary{},
+		},
+		{
+			name:     "FIDE ID 0 matches nobody",
+			arbiter:  Identity{FideID: "0", Name: "Nikto"},
+			leagueID: "1",
+			match:    ownMatch,
+			want:     []conflictSummary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarize(checker.Check(tt.arbiter, tt.leagueID, tt.match))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckClub(t *testing.T) {
+	clubs := map[string]string{
+		"ŠK Prievidza":   "10",
+		"ŠK Prievidza B": "10",
+		"ŠKŠ Dubnica":    "20",
+	}
+	clubOf := func(team string) string { return clubs[team] }
+
+	tests := []struct {
+		name      string
+		clubOf    func(team string) string
+		arbiter   Identity
+		match     data.MatchData
+		wantTeams []string
+	}{
+		{
+			name:      "home team of the arbiter's club",
+			clubOf:    clubOf,
+			arbiter:   Identity{ClubID: "10", ClubName: "ŠK Prievidza", Name: "Novák"},
+			match:     data.MatchData{HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica"},
+			wantTeams: []string{"ŠK Prievidza"},
+		},
+		{
+			name:      "both teams of the arbiter's club",
+			clubOf:    clubOf,
+			arbiter:   Identity{ClubID: "10", ClubName: "ŠK Prievidza", Name: "Novák"},
+			match:     data.MatchData{HomeTeam: "ŠK Prievidza B", GuestTeam: "ŠK Prievidza"},
+			wantTeams: []string{"ŠK Prievidza B", "ŠK Prievidza"},
+		},
+		{
+			name:      "other club",
+			clubOf:    clubOf,
+			arbiter:   Identity{ClubID: "30", ClubName: "ŠK Modra", Name: "Kováč"},
+			match:     data.MatchData{HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica"},
+			wantTeams: []string{},
+		},
+		{
+			name:      "arbiter without a club",
+			clubOf:    clubOf,
+			arbiter:   Identity{Name: "Kováč"},
+			match:     data.MatchData{HomeTeam: "ŠK Nitra", GuestTeam: "ŠK Modra"},
+			wantTeams: []string{},
+		},
+		{
+			name:      "clubs unknown",
+			clubOf:    nil,
+			arbiter:   Identity{ClubID: "10", ClubName: "ŠK Prievidza", Name: "Novák"},
+			match:     data.MatchData{HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica"},
+			wantTeams: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(nil, DefaultMatchDuration, tt.clubOf)
+			teams := []string{}
+			for _, conflict := range checker.CheckClub(tt.arbiter, tt.match) {
+				if conflict.Type != ConflictOwnClub {
+					t.Errorf("conflict type = %q, want %q", conflict.Type, ConflictOwnClub)
+				}
+				teams = append(teams, conflict.Team)
+			}
+			if !reflect.DeepEqual(teams, tt.wantTeams) {
+				t.Errorf("CheckClub() teams = %v, want %v", teams, tt.wantTeams)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Embedded zone database, so Europe/Bratislava resolves on systems without tzdata (e.g. Windows)
+	_ "time/tzdata"
+)
+
+// TimeZoneName is the zone all match times are interpreted in.
+const TimeZoneName = "Europe/Bratislava"
+
+// DateTimeLayout is the canonical text form of a match date and time, e.g. "2025/10/25 11:00".
+const DateTimeLayout = "2006/01/02 15:04"
+
+// dateTimeLayouts are the accepted input forms, tried in order.
+var dateTimeLayouts = []string{
+	DateTimeLayout,
+	"2006/1/2 15:04",
+	"2006-01-02 15:04",
+	"2.1.2006 15:04",
+	"2. 1. 2006 15:04",
+}
+
+// dateLayouts are the accepted input forms of a date without a time, tried in order.
+var dateLayouts = []string{
+	"2006/01/02",
+	"2006/1/2",
+	"2006-01-02",
+	"2.1.2006",
+	"2. 1. 2006",
+}
+
+// TimeZone is the location used for parsing and formatting match times.
+// Daylight saving time transitions follow the zone rules.
+var TimeZone = loadTimeZone()
+
+// loadTimeZone loads TimeZoneName from the embedded zone database.
+func loadTimeZone() *time.Location {
+	location, err := time.LoadLocation(TimeZoneName)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load time zone %s: %v", TimeZoneName, err))
+	}
+	return location
+}
+
+// ParseDateTime parses a match date and time such as "2025/10/25 11:00" in the Europe/Bratislava zone.
+// A missing value, a date without a time and unknown formats are reported as errors.
+func ParseDateTime(value string) (time.Time, error) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return time.Time{}, fmt.Errorf("date and time are missing")
+	}
+
+	for _, layout := range dateTimeLayouts {
+		wall, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, 0, TimeZone)
+		// Times skipped by the spring daylight saving change are shifted by time.Date, reject them instead
+		if t.Hour() != wall.Hour() || t.Minute() != wall.Minute() {
+			return time.Time{}, fmt.Errorf("%q does not exist in %s (daylight saving time change)", value, TimeZoneName)
+		}
+		return t, nil
+	}
+
+	if !strings.Contains(value, ":") {
+		return time.Time{}, fmt.Errorf("time is missing in %q", value)
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date and time %q, expected YYYY/MM/DD HH:MM", value)
+}
+
+// FormatDateTime returns the canonical text form of t in the Europe/Bratislava zone.
+func FormatDateTime(t time.Time) string {
+	return t.In(TimeZone).Format(DateTimeLayout)
+}
+
+// ParseDate parses a date without a time such as "2025/10/25" as midnight in the Europe/Bratislava zone.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return time.Time{}, fmt.Errorf("date is missing")
+	}
+
+	for _, layout := range dateLayouts {
+		day, err := time.Parse(layout, value)
+		if err == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, TimeZone), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q, expected YYYY/MM/DD", value)
+}
@@ -0,0 +1,22 @@
+package data
+
+import (
+	"fmt"
+)
+
+// MatchID returns the stable identifier of a match, e.g. "1234567-R3-2" for pairing 2 of round 3.
+// It only depends on the tournament, the round and the pairing number from chess-results,
+// so it stays the same when the schedule is reloaded or the matches are shown in another order.
+func MatchID(tournamentID string, round, pairing int) string {
+	return fmt.Sprintf("%s-R%d-%d", tournamentID, round, pairing)
+}
+
+// AssignMatchIDs sets the ID of every match in rounds from the tournament ID and the match's pairing number.
+func AssignMatchIDs(rounds []Round, tournamentID string) {
+	for i := range rounds {
+		for j := range rounds[i].Matches {
+			match := &rounds[i].Matches[j]
+			match.ID = MatchID(tournamentID, rounds[i].Number, match.Pairing)
+		}
+	}
+}
@@ -4,16 +4,22 @@ package data
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 // PDFData represents the structured data for PDF generation.
 // It contains all necessary information to fill out a delegation form PDF.
 type PDFData struct {
-	Arbiter       ArbiterData  // Information about the assigned arbiter
-	League        LeagueData   // Information about the chess league
-	Match         MatchData    // Information about the specific match
-	Director      DirectorData // Information about the league director
-	ContactPerson string       // Contact person for the delegation
+	Arbiter        ArbiterData  // Information about the assigned arbiter
+	League         LeagueData   // Information about the chess league
+	Match          MatchData    // Information about the specific match
+	Director       DirectorData // Information about the league director
+	ContactPerson  string       // Contact person for the delegation
+	DocumentNumber string       // Delegation number, assigned by position in the batch when empty
+	Role           string       // Role of Arbiter at the match (see RoleChiefArbiter), empty for a single arbiter
+	Officials      []Official   // All arbiters delegated to the match in order, empty for a single arbiter
+	Matches        []MatchData  // All matches of a venue-grouped delegation (Match is the first), empty for a single match
 }
 
 // ArbiterData contains arbiter information extracted from the chess.sk API.
@@ -23,6 +29,11 @@ type ArbiterData struct {
 	PlayerID  string // Arbiter's player ID in the chess system
 }
 
+// FullName returns the arbiter's name as "Firstname Lastname".
+func (a ArbiterData) FullName() string {
+	return strings.TrimSpace(a.FirstName + " " + a.LastName)
+}
+
 // LeagueData contains league information extracted from the chess.sk API.
 type LeagueData struct {
 	Name string // Name of the chess league
@@ -31,10 +42,13 @@ type LeagueData struct {
 
 // MatchData contains match information for the delegation.
 type MatchData struct {
-	HomeTeam  string // Name of the home team
-	GuestTeam string // Name of the guest team
-	DateTime  string // Date and time of the match
-	Address   string // Venue address for the match
+	MatchID      string // Stable match identifier (see MatchID), empty for matches entered by hand
+	Round        int    // Round number the match belongs to (0 when unknown)
+	HomeTeam     string // Name of the home team
+	GuestTeam    string // Name of the guest team
+	DateTime     string // Date and time of the match
+	Address      string // Venue address for the match
+	FreeDateTime bool   // DateTime is deliberate free text (e.g. "podľa dohody"), printed as entered and not validated
 }
 
 // DirectorData contains director information extracted from the chess.sk API.
@@ -45,17 +59,38 @@ type DirectorData struct {
 // Round represents a single round of matches in a chess league.
 // Each round contains multiple matches played at the same time.
 type Round struct {
-	Number   int         `json:"number"`   // Round number (1, 2, 3, etc.)
-	DateTime string      `json:"dateTime"` // Date and time of the round (e.g., "2025/10/25 at 11:00")
-	Matches  []MatchInfo `json:"matches"`  // List of matches in this round
+	Number        int         `json:"number"`                  // Round number (1, 2, 3, etc.)
+	DateTime      string      `json:"dateTime"`                // Date and time of the round (e.g., "2025/10/25 11:00")
+	StartsAt      *time.Time  `json:"startsAt,omitempty"`      // Parsed DateTime in Europe/Bratislava, nil when missing or invalid
+	DateTimeError string      `json:"dateTimeError,omitempty"` // Why DateTime could not be parsed
+	Status        string      `json:"status"`                  // RoundPlayed, RoundPartial or RoundUpcoming
+	Matches       []MatchInfo `json:"matches"`                 // List of matches in this round
 }
 
 // MatchInfo contains match-specific details extracted from Excel files.
 type MatchInfo struct {
-	HomeTeam  string `json:"homeTeam"`  // Name of the home team
-	GuestTeam string `json:"guestTeam"` // Name of the guest team
-	DateTime  string `json:"dateTime"`  // Date and time of the match
-	Address   string `json:"address"`   // Venue address (usually empty in Excel format)
+	ID            string     `json:"id"`                      // Stable match identifier (see MatchID)
+	Pairing       int        `json:"pairing"`                 // Pairing number within the round, from the export's "No." column
+	HomeTeam      string     `json:"homeTeam"`                // Name of the home team
+	GuestTeam     string     `json:"guestTeam"`               // Name of the guest team
+	DateTime      string     `json:"dateTime"`                // Date and time of the match (e.g., "2025/10/25 11:00")
+	StartsAt      *time.Time `json:"startsAt,omitempty"`      // Parsed DateTime in Europe/Bratislava, nil when missing or invalid
+	DateTimeError string     `json:"dateTimeError,omitempty"` // Why DateTime could not be parsed
+	Address       string     `json:"address"`                 // Venue address (usually empty in Excel format)
+	HomeResult    string     `json:"homeResult,omitempty"`    // Home team score ("4.5", "+" or "-" for forfeits), empty before the match
+	GuestResult   string     `json:"guestResult,omitempty"`   // Guest team score, same format as HomeResult
+	Played        bool       `json:"played"`                  // Whether the export already has a result for the match
+	Placeholder   bool       `json:"placeholder,omitempty"`   // Teams are not decided yet, HomeTeam and GuestTeam hold labels such as "Víťaz QF1"
+}
+
+// UndecidedTeam stands in for a team that chess-results does not list yet, e.g. in a cup round before the draw.
+const UndecidedTeam = "(neurčené)"
+
+// UndecidedPairing is a numbered pairing row of a schedule export that lacks a team, e.g. a cup round before the draw.
+// It is not part of the parsed rounds; it only becomes a placeholder match when a stored fixture covers it.
+type UndecidedPairing struct {
+	Round int       `json:"round"` // Round the row belongs to
+	Match MatchInfo `json:"match"` // Row as parsed, the missing team names are empty
 }
 
 // League represents a league from the chess.sk API.
@@ -69,6 +104,7 @@ type League struct {
 	DirectorSurname   string `json:"directorSurname"`   // Director's surname
 	DirectorFirstName string `json:"directorFirstName"` // Director's first name
 	DirectorEmail     string `json:"directorEmail"`     // Director's email address
+	Custom            bool   `json:"custom,omitempty"`  // Registered in this application, not returned by the chess.sk API
 }
 
 // Arbiter represents an arbiter from the chess.sk API.
@@ -87,6 +123,17 @@ type Arbiter struct {
 	ArbiterLevel string `json:"ArbiterLevel"` // Arbiter's certification level
 }
 
+// Club represents a club from the chess.sk club registry.
+// The registry records use the same KlubId/KlubName keys as the arbiter records.
+type Club struct {
+	KlubId        string `json:"KlubId"`        // Unique identifier for the club (Arbiter.KlubId)
+	KlubName      string `json:"KlubName"`      // Club name
+	Region        string `json:"Region"`        // Regional chess association
+	ContactPerson string `json:"ContactPerson"` // Club contact person
+	ContactEmail  string `json:"ContactEmail"`  // Contact email
+	ContactPhone  string `json:"ContactPhone"`  // Contact phone
+}
+
 // NewPDFData creates a new PDFData instance with empty fields.
 // Returns a pointer to a new PDFData struct ready for population.
 func NewPDFData() *PDFData {
@@ -119,7 +166,50 @@ func (p *PDFData) Validate() error {
 	if p.Director.Contact == "" {
 		return fmt.Errorf("director contact is required")
 	}
-	return nil
+	if len(p.Officials) == 0 && p.Role != "" {
+		// A single arbiter may leave the role empty, but a role that is set must be known
+		return ValidateOfficials(p.MatchOfficials())
+	}
+	return ValidateOfficials(p.Officials)
+}
+
+// MatchOfficials returns every arbiter delegated to the match:
+// the Officials when there are several, otherwise the single Arbiter with its Role.
+func (p *PDFData) MatchOfficials() []Official {
+	if len(p.Officials) > 0 {
+		return p.Officials
+	}
+	return []Official{{Arbiter: p.Arbiter, Role: p.Role}}
+}
+
+// OfficialDocumentNumber returns the number of the letter addressed to the official at index when every official
+// gets a letter of their own: DocumentNumber with a "-1", "-2", ... suffix, so no two letters share a number.
+// A single arbiter keeps DocumentNumber; an empty number stays empty and is assigned by position in the batch.
+func (p *PDFData) OfficialDocumentNumber(index int) string {
+	if len(p.Officials) < 2 || p.DocumentNumber == "" {
+		return p.DocumentNumber
+	}
+	return fmt.Sprintf("%s-%d", p.DocumentNumber, index+1)
+}
+
+// CoveredMatches returns every match the delegation covers: the Matches of a venue group, otherwise Match.
+func (p *PDFData) CoveredMatches() []MatchData {
+	if len(p.Matches) > 0 {
+		return p.Matches
+	}
+	return []MatchData{p.Match}
+}
+
+// CoOfficials returns the other arbiters delegated to the match, i.e. Officials without the addressed Arbiter.
+func (p *PDFData) CoOfficials() []Official {
+	var result []Official
+	for _, official := range p.Officials {
+		if official.Arbiter == p.Arbiter && official.Role == p.Role {
+			continue
+		}
+		result = append(result, official)
+	}
+	return result
 }
 
 // SetLeague sets the league information in the PDFData.
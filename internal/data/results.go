@@ -0,0 +1,40 @@
+package data
+
+// Round statuses reported in Round.Status.
+const (
+	RoundPlayed   = "played"   // Every match of the round has a result
+	RoundPartial  = "partial"  // Some matches have a result, others are still to be played
+	RoundUpcoming = "upcoming" // No match of the round has a result yet
+)
+
+// UpdateStatus sets the round status from the results of its matches.
+// A round without matches is upcoming.
+func (r *Round) UpdateStatus() {
+	played := 0
+	for _, match := range r.Matches {
+		if match.Played {
+			played++
+		}
+	}
+
+	switch {
+	case played == 0:
+		r.Status = RoundUpcoming
+	case played == len(r.Matches):
+		r.Status = RoundPlayed
+	default:
+		r.Status = RoundPartial
+	}
+}
+
+// UnplayedRounds returns the rounds that still have matches to be played, in their original order.
+// Partially played rounds are kept whole so their remaining matches can still be delegated.
+func UnplayedRounds(rounds []Round) []Round {
+	result := make([]Round, 0, len(rounds))
+	for _, round := range rounds {
+		if round.Status != RoundPlayed {
+			result = append(result, round)
+		}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"strings"
+)
+
+// TeamRoster is the composition of one team as published on chess-results.
+type TeamRoster struct {
+	Team         string         `json:"team"`         // Team name as used in the pairings
+	StartingRank int            `json:"startingRank"` // Team's number in the starting rank list
+	Players      []RosterPlayer `json:"players"`      // Players in board order
+}
+
+// RosterPlayer is a player registered for a team.
+type RosterPlayer struct {
+	Board      int    `json:"board"`      // Board order within the team (1 = first board)
+	Title      string `json:"title"`      // FIDE title (GM, IM, FM, ...), empty when none
+	Name       string `json:"name"`       // Player name as "Surname, Firstname"
+	PlayerID   string `json:"playerId"`   // National player ID (matches Arbiter.PlayerId), empty when not published
+	FideID     string `json:"fideId"`     // FIDE ID, empty when the player has none
+	Rating     int    `json:"rating"`     // Rating shown in the export, 0 when unrated
+	Federation string `json:"federation"` // Federation code (e.g. "SVK")
+}
+
+// Rosters holds the team rosters of one tournament.
+type Rosters []TeamRoster
+
+// NormalizeTeamName makes team names comparable regardless of case and spacing.
+func NormalizeTeamName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ForTeam returns the roster of the team with the given name as used in MatchInfo, or nil when there is none.
+func (r Rosters) ForTeam(team string) *TeamRoster {
+	key := NormalizeTeamName(team)
+	for i := range r {
+		if NormalizeTeamName(r[i].Team) == key {
+			return &r[i]
+		}
+	}
+	return nil
+}
+
+// UnmatchedTeams returns the teams playing in rounds that have no roster, in order of first appearance.
+func (r Rosters) UnmatchedTeams(rounds []Round) []string {
+	seen := make(map[string]bool)
+	unmatched := []string{}
+	for _, round := range rounds {
+		for _, match := range round.Matches {
+			for _, team := range []string{match.HomeTeam, match.GuestTeam} {
+				key := NormalizeTeamName(team)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				if r.ForTeam(team) == nil {
+					unmatched = append(unmatched, team)
+				}
+			}
+		}
+	}
+	return unmatched
+}
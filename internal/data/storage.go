@@ -38,6 +38,15 @@ func (sd *SessionData) Get(key string) (interface{}, bool) {
 	return data, exists
 }
 
+// Delete removes the data stored with the given key.
+// This method is thread-safe and uses write locking.
+func (sd *SessionData) Delete(key string) {
+	sd.mutex.Lock()
+	defer sd.mutex.Unlock()
+
+	delete(sd.data, key)
+}
+
 // Set stores data in session storage with the given key.
 // This method is thread-safe and uses write locking.
 func (sd *SessionData) Set(key string, value interface{}) {
@@ -258,12 +267,7 @@ func (sd *SessionData) GetArbiterByID(arbiterID int) (*Arbiter, error) {
 // The leagueID parameter is converted to string for comparison with the LeagueId field.
 // Returns a pointer to the found League or an error if not found or data not loaded.
 func (sd *SessionData) GetLeagueByID(leagueID int) (*League, error) {
-	rawData, exists := sd.Get("leagues")
-	if !exists {
-		return nil, fmt.Errorf("leagues data not loaded")
-	}
-
-	leagues, err := ProcessLeaguesData(rawData)
+	leagues, err := sd.GetAllLeagues()
 	if err != nil {
 		return nil, err
 	}
@@ -289,14 +293,134 @@ func (sd *SessionData) GetAllArbiters() ([]Arbiter, error) {
 	return ProcessArbitersData(rawData)
 }
 
-// GetAllLeagues returns all loaded leagues from the session storage.
+// GetAllLeagues returns all loaded leagues from the session storage, followed by the custom leagues.
 // It retrieves the raw leagues data and processes it into a slice of League structs.
-// Returns an error if leagues data has not been loaded yet.
+// Returns an error if neither leagues data nor custom leagues have been loaded yet.
 func (sd *SessionData) GetAllLeagues() ([]League, error) {
+	custom := sd.GetCustomLeagues()
 	rawData, exists := sd.Get("leagues")
 	if !exists {
+		if len(custom) > 0 {
+			return custom, nil
+		}
 		return nil, fmt.Errorf("leagues data not loaded")
 	}
 
-	return ProcessLeaguesData(rawData)
+	leagues, err := ProcessLeaguesData(rawData)
+	if err != nil {
+		return nil, err
+	}
+	return append(leagues, custom...), nil
+}
+
+// SetCustomLeagues stores the leagues registered in this application, see League.Custom.
+func (sd *SessionData) SetCustomLeagues(leagues []League) {
+	sd.Set("custom_leagues", leagues)
+}
+
+// GetCustomLeagues returns the leagues registered in this application.
+func (sd *SessionData) GetCustomLeagues() []League {
+	rawData, _ := sd.Get("custom_leagues")
+	leagues, _ := rawData.([]League)
+	return append([]League{}, leagues...)
+}
+
+// ProcessClubsData processes raw API data and extracts a slice of Club structs.
+// This is a convenience function that calls ProcessData with the Club type.
+// It's used specifically for processing clubs data from the chess.sk API.
+func ProcessClubsData(rawData interface{}) ([]Club, error) {
+	return ProcessData[Club](rawData)
+}
+
+// clubFields are the keys of a chess.sk club record that Club reads.
+var clubFields = []string{"KlubId", "KlubName", "Region", "ContactPerson", "ContactEmail", "ContactPhone"}
+
+// CheckClubsData verifies that raw API data is a club list: a non-empty "data" array
+// whose records all carry KlubId and KlubName. It also returns the other Club fields
+// that no record carries, so a changed API shows up instead of leaving them empty.
+func CheckClubsData(rawData interface{}) ([]string, error) {
+	dataMap, ok := rawData.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("response is not a JSON object")
+	}
+	records, ok := dataMap["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("response has no \"data\" array")
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("response contains no clubs")
+	}
+
+	seen := make(map[string]bool)
+	for i, item := range records {
+		record, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record %d is not a JSON object", i)
+		}
+		for _, key := range clubFields {
+			if value, ok := record[key]; ok && value != nil {
+				seen[key] = true
+			}
+		}
+		if _, ok := record["KlubId"]; !ok {
+			return nil, fmt.Errorf("record %d has no KlubId", i)
+		}
+		if _, ok := record["KlubName"]; !ok {
+			return nil, fmt.Errorf("record %d has no KlubName", i)
+		}
+	}
+
+	var missing []string
+	for _, key := range clubFields {
+		if !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	return missing, nil
+}
+
+// GetAllClubs returns all loaded clubs from the session storage.
+// Returns an error if clubs data has not been loaded yet.
+func (sd *SessionData) GetAllClubs() ([]Club, error) {
+	rawData, exists := sd.Get("clubs")
+	if !exists {
+		return nil, fmt.Errorf("clubs data not loaded")
+	}
+
+	return ProcessClubsData(rawData)
+}
+
+// GetClubByID finds a club by its KlubId from the loaded clubs data.
+// Returns a pointer to the found Club or an error if not found or data not loaded.
+func (sd *SessionData) GetClubByID(clubID string) (*Club, error) {
+	clubs, err := sd.GetAllClubs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, club := range clubs {
+		if club.KlubId == clubID {
+			return &club, nil
+		}
+	}
+
+	return nil, fmt.Errorf("club with ID %s not found", clubID)
+}
+
+// GetClubByName finds a club by its name from the loaded clubs data.
+// Names are compared ignoring case and spacing (see NormalizeTeamName).
+func (sd *SessionData) GetClubByName(name string) (*Club, error) {
+	clubs, err := sd.GetAllClubs()
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := NormalizeTeamName(name)
+	for _, club := range clubs {
+		if NormalizeTeamName(club.KlubName) == normalized {
+			return &club, nil
+		}
+	}
+
+	return nil, fmt.Errorf("club %q not found", name)
 }
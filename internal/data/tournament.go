@@ -0,0 +1,130 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Arbiter roles at a tournament or match, listed on delegations in this order.
+const (
+	RoleChiefArbiter  = "chief_arbiter"  // Chief arbiter (hlavný rozhodca)
+	RoleDeputyArbiter = "deputy_arbiter" // Deputy chief arbiter (zástupca hlavného rozhodcu)
+	RoleArbiter       = "arbiter"        // Arbiter (rozhodca)
+)
+
+// roleOrder ranks the roles for sorting, see SortOfficials.
+var roleOrder = map[string]int{
+	RoleChiefArbiter:  0,
+	RoleDeputyArbiter: 1,
+	RoleArbiter:       2,
+}
+
+// roleLabels are the Slovak role names printed on delegations.
+var roleLabels = map[string]string{
+	RoleChiefArbiter:  "hlavný rozhodca",
+	RoleDeputyArbiter: "zástupca hlavného rozhodcu",
+	RoleArbiter:       "rozhodca",
+}
+
+// RoleLabel returns the Slovak name of an arbiter role, or the role itself when it is unknown.
+func RoleLabel(role string) string {
+	if label, ok := roleLabels[role]; ok {
+		return label
+	}
+	return role
+}
+
+// Official is an arbiter delegated with a role.
+type Official struct {
+	Arbiter ArbiterData // Delegated arbiter
+	Role    string      // RoleChiefArbiter, RoleDeputyArbiter or RoleArbiter
+}
+
+// OfficialsWithRole returns the officials with the given role, in their original order.
+func OfficialsWithRole(officials []Official, role string) []Official {
+	var result []Official
+	for _, official := range officials {
+		if official.Role == role {
+			result = append(result, official)
+		}
+	}
+	return result
+}
+
+// TournamentData contains the details of an individual tournament (e.g. a Slovak championship).
+type TournamentData struct {
+	Name      string // Tournament name
+	Year      string // Season year (e.g., "2024/2025")
+	StartDate string // First day of the tournament (e.g., "2025/10/25")
+	EndDate   string // Last day of the tournament, same as StartDate for one-day tournaments
+	Venue     string // Venue address
+}
+
+// TournamentPDFData represents the structured data of a tournament delegation.
+// Unlike PDFData it covers a date range instead of a match and delegates several arbiters in one document.
+type TournamentPDFData struct {
+	Tournament     TournamentData // Information about the tournament
+	Officials      []Official     // Delegated arbiters with their roles
+	Director       DirectorData   // Information about the tournament director
+	ContactPerson  string         // Contact person for the delegation
+	DocumentNumber string         // Delegation number, "001" when empty
+}
+
+// Validate checks that the tournament delegation has a name, director, valid date range and officials.
+// Every official needs a known role and there can be at most one chief arbiter.
+func (p *TournamentPDFData) Validate() error {
+	if p.Tournament.Name == "" {
+		return fmt.Errorf("tournament name is required")
+	}
+	if p.Director.Contact == "" {
+		return fmt.Errorf("director contact is required")
+	}
+
+	start, err := ParseDate(p.Tournament.StartDate)
+	if err != nil {
+		return fmt.Errorf("start date: %v", err)
+	}
+	end := start
+	if p.Tournament.EndDate != "" {
+		if end, err = ParseDate(p.Tournament.EndDate); err != nil {
+			return fmt.Errorf("end date: %v", err)
+		}
+	}
+	if end.Before(start) {
+		return fmt.Errorf("tournament ends before it starts")
+	}
+
+	if len(p.Officials) == 0 {
+		return fmt.Errorf("at least one arbiter is required")
+	}
+	return ValidateOfficials(p.Officials)
+}
+
+// ValidateOfficials checks that every official has a known role and that there is at most one chief arbiter.
+func ValidateOfficials(officials []Official) error {
+	chiefArbiters := 0
+	for _, official := range officials {
+		if _, ok := roleOrder[official.Role]; !ok {
+			return fmt.Errorf("unknown role %q of arbiter %s", official.Role, official.Arbiter.FullName())
+		}
+		if official.Role == RoleChiefArbiter {
+			chiefArbiters++
+		}
+	}
+	if chiefArbiters > 1 {
+		return fmt.Errorf("only one chief arbiter can be delegated")
+	}
+	return nil
+}
+
+// OfficialsWithRole returns the officials delegated with the given role, in their original order.
+func (p *TournamentPDFData) OfficialsWithRole(role string) []Official {
+	return OfficialsWithRole(p.Officials, role)
+}
+
+// SortOfficials orders officials by role (chief arbiter first), keeping the order within a role.
+func SortOfficials(officials []Official) {
+	sort.SliceStable(officials, func(i, j int) bool {
+		return roleOrder[officials[i].Role] < roleOrder[officials[j].Role]
+	})
+}
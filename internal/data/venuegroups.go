@@ -0,0 +1,62 @@
+package data
+
+import (
+	"strings"
+)
+
+// VenueGroup is a set of matches played in the same hall at the same time, e.g. in a centralized round.
+// One arbiter can cover all of them with a single delegation.
+type VenueGroup struct {
+	Address  string   `json:"address"`  // Venue address shared by the matches
+	DateTime string   `json:"dateTime"` // Start shared by the matches
+	Round    int      `json:"round"`    // Round of the first match
+	MatchIDs []string `json:"matchIds"` // Matches played together, in schedule order
+}
+
+// venueKey identifies a hall and start time; addresses are compared without case and repeated spaces.
+func venueKey(match MatchInfo) string {
+	address := strings.ToLower(strings.Join(strings.Fields(match.Address), " "))
+	if address == "" {
+		return ""
+	}
+	start := strings.Join(strings.Fields(match.DateTime), " ")
+	if match.StartsAt != nil {
+		start = FormatDateTime(*match.StartsAt)
+	}
+	return address + "|" + start
+}
+
+// GroupMatchesByVenue returns the groups of at least two unplayed matches sharing the address and start time.
+// Matches without an address or ID are never grouped. Groups are ordered by their first match.
+func GroupMatchesByVenue(rounds []Round) []VenueGroup {
+	var groups []VenueGroup
+	index := make(map[string]int)
+
+	for _, round := range rounds {
+		for _, match := range round.Matches {
+			key := venueKey(match)
+			if key == "" || match.ID == "" || match.Played {
+				continue
+			}
+			if i, ok := index[key]; ok {
+				groups[i].MatchIDs = append(groups[i].MatchIDs, match.ID)
+				continue
+			}
+			index[key] = len(groups)
+			groups = append(groups, VenueGroup{
+				Address:  match.Address,
+				DateTime: match.DateTime,
+				Round:    round.Number,
+				MatchIDs: []string{match.ID},
+			})
+		}
+	}
+
+	result := []VenueGroup{}
+	for _, group := range groups {
+		if len(group.MatchIDs) > 1 {
+			result = append(result, group)
+		}
+	}
+	return result
+}
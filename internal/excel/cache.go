@@ -0,0 +1,365 @@
+package excel
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// CacheDir holds the last downloaded export of every tournament.
+const CacheDir = "assets/cache/"
+
+// CacheMaxAgeEnv is the environment variable with the cache max age as a Go duration (e.g. "30m", "2h").
+const CacheMaxAgeEnv = "SCHEDULE_CACHE_MAX_AGE"
+
+// DefaultCacheMaxAge is used when CacheMaxAgeEnv is not set or invalid.
+const DefaultCacheMaxAge = 15 * time.Minute
+
+// CachedSchedule is a parsed schedule together with its download record.
+type CachedSchedule struct {
+	TournamentID string       `json:"tournamentId"` // chess-results tournament ID
+	FilePath     string       `json:"-"`            // Cached Excel file
+	Result       *ParseResult `json:"-"`            // Parsed rounds, dialect and warnings
+	FetchedAt    time.Time    `json:"fetchedAt"`    // When the file was downloaded from chess-results
+	FromCache    bool         `json:"fromCache"`    // Whether this request was served without downloading
+	Stale        bool         `json:"stale"`        // Served past max age because the download failed
+}
+
+// tournamentLocks serializes the downloads of one tournament, so a slow chess-results response
+// only delays requests for that tournament and never the cache hits of others.
+type tournamentLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the tournament and returns the function that unlocks it.
+func (l *tournamentLocks) lock(tournamentID string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+	lock := l.locks[tournamentID]
+	if lock == nil {
+		lock = &sync.Mutex{}
+		l.locks[tournamentID] = lock
+	}
+	l.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// ScheduleCache keeps downloaded chess-results exports and their parsed rounds per tournament.
+// Files are kept on disk so the cache survives restarts; parsed results are kept in memory.
+// mu only guards the entries map; downloads hold the tournament's lock instead.
+type ScheduleCache struct {
+	mu        sync.Mutex
+	downloads tournamentLocks
+	dir       string
+	maxAge    time.Duration
+	entries   map[string]*CachedSchedule
+}
+
+// NewScheduleCache creates a cache storing files in dir and re-downloading schedules older than maxAge.
+func NewScheduleCache(dir string, maxAge time.Duration) *ScheduleCache {
+	return &ScheduleCache{
+		dir:     dir,
+		maxAge:  maxAge,
+		entries: make(map[string]*CachedSchedule),
+	}
+}
+
+// CacheMaxAgeFromEnv reads the cache max age from CacheMaxAgeEnv, falling back to DefaultCacheMaxAge.
+func CacheMaxAgeFromEnv() time.Duration {
+	value := os.Getenv(CacheMaxAgeEnv)
+	if value == "" {
+		return DefaultCacheMaxAge
+	}
+	maxAge, err := time.ParseDuration(value)
+	if err != nil || maxAge < 0 {
+		logger.Error("Invalid %s %q, using %s", CacheMaxAgeEnv, value, DefaultCacheMaxAge)
+		return DefaultCacheMaxAge
+	}
+	return maxAge
+}
+
+// MaxAge returns how long a downloaded schedule is considered fresh.
+func (c *ScheduleCache) MaxAge() time.Duration {
+	return c.maxAge
+}
+
+// ForLeague returns the parsed schedule of the league's tournament, see Get.
+func (c *ScheduleCache) ForLeague(league *data.League, refresh bool) (*CachedSchedule, error) {
+	tournamentID, err := ExtractTournamentIDFromLeague(league)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract tournament ID: %v", err)
+	}
+	return c.Get(tournamentID, refresh)
+}
+
+// Get returns the parsed schedule of a tournament.
+// A cached schedule younger than the max age is returned without contacting chess-results unless refresh is set.
+// When the download fails, an older cached copy is returned and marked as stale.
+func (c *ScheduleCache) Get(tournamentID string, refresh bool) (*CachedSchedule, error) {
+	unlock := c.downloads.lock(tournamentID)
+	defer unlock()
+
+	entry := c.entry(tournamentID)
+	if entry != nil && !refresh && time.Since(entry.FetchedAt) < c.maxAge {
+		logger.Debug("Serving cached schedule for tournament %s (fetched %s)", tournamentID, entry.FetchedAt.Format(time.RFC3339))
+		return entry.served(false), nil
+	}
+
+	fresh, err := c.download(tournamentID)
+	if err != nil {
+		if entry != nil {
+			logger.Error("Failed to refresh schedule for tournament %s, serving copy from %s: %v",
+				tournamentID, entry.FetchedAt.Format(time.RFC3339), err)
+			return entry.served(true), nil
+		}
+		return nil, err
+	}
+
+	c.store(tournamentID, fresh)
+	result := *fresh
+	return &result, nil
+}
+
+// Cached returns the cached schedule of a tournament without downloading it, or nil when there is none.
+func (c *ScheduleCache) Cached(tournamentID string) *CachedSchedule {
+	entry := c.entry(tournamentID)
+	if entry == nil {
+		return nil
+	}
+	return entry.served(time.Since(entry.FetchedAt) >= c.maxAge)
+}
+
+// Status returns the download record of every cached tournament, most recent first.
+func (c *ScheduleCache) Status() []CachedSchedule {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := make([]CachedSchedule, 0, len(c.entries))
+	for _, entry := range c.entries {
+		status = append(status, *entry)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].FetchedAt.After(status[j].FetchedAt)
+	})
+	return status
+}
+
+// entry returns the tournament's entry, restoring it from disk after a restart. Returns nil when there is none.
+func (c *ScheduleCache) entry(tournamentID string) *CachedSchedule {
+	c.mu.Lock()
+	entry := c.entries[tournamentID]
+	c.mu.Unlock()
+
+	if entry == nil {
+		entry = c.loadFromDisk(tournamentID)
+	}
+	return entry
+}
+
+// store replaces the tournament's entry. Stored entries are never modified, requests get copies.
+func (c *ScheduleCache) store(tournamentID string, entry *CachedSchedule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[tournamentID] = entry
+}
+
+// served returns a copy of the entry flagged as served from the cache.
+func (e *CachedSchedule) served(stale bool) *CachedSchedule {
+	served := *e
+	served.FromCache = true
+	served.Stale = stale
+	return &served
+}
+
+// cachePath returns the cached file path of a tournament.
+func (c *ScheduleCache) cachePath(tournamentID string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("chess_results_%s.xlsx", tournamentID))
+}
+
+// loadFromDisk restores a tournament's entry from a file cached before a restart.
+// The file's modification time is the download time. Returns nil when there is no usable file.
+func (c *ScheduleCache) loadFromDisk(tournamentID string) *CachedSchedule {
+	filePath := c.cachePath(tournamentID)
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil
+	}
+
+	result, err := ParseChessResultsExcel(filePath)
+	if err != nil {
+		logger.Error("Ignoring unreadable cached schedule %s: %v", filePath, err)
+		return nil
+	}
+	data.AssignMatchIDs(result.Rounds, tournamentID)
+
+	entry := &CachedSchedule{
+		TournamentID: tournamentID,
+		FilePath:     filePath,
+		Result:       result,
+		FetchedAt:    info.ModTime(),
+	}
+	c.store(tournamentID, entry)
+	return entry
+}
+
+// download fetches and parses the tournament's export and replaces the cached file.
+// The previous file is only replaced once the new one parsed successfully.
+func (c *ScheduleCache) download(tournamentID string) (*CachedSchedule, error) {
+	tempPath, err := DownloadChessResultsExcel(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ParseChessResultsExcel(tempPath)
+	if err != nil {
+		CleanupTempFile(tempPath)
+		return nil, fmt.Errorf("failed to parse Excel file: %v", err)
+	}
+	data.AssignMatchIDs(result.Rounds, tournamentID)
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		CleanupTempFile(tempPath)
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	filePath := c.cachePath(tournamentID)
+	if err := os.Rename(tempPath, filePath); err != nil {
+		CleanupTempFile(tempPath)
+		return nil, fmt.Errorf("failed to store cached schedule: %v", err)
+	}
+
+	fetchedAt := time.Now()
+	os.Chtimes(filePath, fetchedAt, fetchedAt)
+
+	logger.Info("Downloaded schedule for tournament %s: %d rounds (layout: %s, warnings: %d)",
+		tournamentID, len(result.Rounds), result.Dialect.Layout, len(result.Warnings))
+
+	return &CachedSchedule{
+		TournamentID: tournamentID,
+		FilePath:     filePath,
+		Result:       result,
+		FetchedAt:    fetchedAt,
+	}, nil
+}
+
+// CachedRosters are parsed team rosters together with their download time.
+type CachedRosters struct {
+	TournamentID string        `json:"tournamentId"` // chess-results tournament ID
+	Result       *RosterResult `json:"-"`            // Parsed rosters and warnings
+	FetchedAt    time.Time     `json:"fetchedAt"`    // When the export was downloaded from chess-results
+	FromCache    bool          `json:"fromCache"`    // Whether this request was served without downloading
+	Stale        bool          `json:"stale"`        // Served past max age because the download failed
+}
+
+// RosterCache keeps parsed team rosters per tournament in memory.
+// Rosters change rarely during a season, so they are not kept on disk.
+// mu only guards the entries map; downloads hold the tournament's lock instead.
+type RosterCache struct {
+	mu        sync.Mutex
+	downloads tournamentLocks
+	maxAge    time.Duration
+	entries   map[string]*CachedRosters
+}
+
+// NewRosterCache creates a cache re-downloading rosters older than maxAge.
+func NewRosterCache(maxAge time.Duration) *RosterCache {
+	return &RosterCache{
+		maxAge:  maxAge,
+		entries: make(map[string]*CachedRosters),
+	}
+}
+
+// ForLeague returns the team rosters of the league's tournament, see Get.
+func (c *RosterCache) ForLeague(league *data.League, refresh bool) (*CachedRosters, error) {
+	tournamentID, err := ExtractTournamentIDFromLeague(league)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract tournament ID: %v", err)
+	}
+	return c.Get(tournamentID, refresh)
+}
+
+// Get returns the team rosters of a tournament, downloading them when there is no fresh copy or refresh is set.
+// When the download fails, an older copy is returned and marked as stale.
+func (c *RosterCache) Get(tournamentID string, refresh bool) (*CachedRosters, error) {
+	unlock := c.downloads.lock(tournamentID)
+	defer unlock()
+
+	c.mu.Lock()
+	entry := c.entries[tournamentID]
+	c.mu.Unlock()
+
+	if entry != nil && !refresh && time.Since(entry.FetchedAt) < c.maxAge {
+		served := *entry
+		served.FromCache = true
+		return &served, nil
+	}
+
+	fresh, err := downloadRosters(tournamentID)
+	if err != nil {
+		if entry != nil {
+			logger.Error("Failed to refresh rosters for tournament %s, serving copy from %s: %v",
+				tournamentID, entry.FetchedAt.Format(time.RFC3339), err)
+			served := *entry
+			served.FromCache = true
+			served.Stale = true
+			return &served, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[tournamentID] = fresh
+	c.mu.Unlock()
+
+	result := *fresh
+	return &result, nil
+}
+
+// Cached returns the cached rosters of a tournament without downloading them, or nil when there are none.
+func (c *RosterCache) Cached(tournamentID string) *CachedRosters {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entries[tournamentID]
+	if entry == nil {
+		return nil
+	}
+	served := *entry
+	served.FromCache = true
+	served.Stale = time.Since(entry.FetchedAt) >= c.maxAge
+	return &served
+}
+
+// downloadRosters fetches and parses the team composition export of a tournament.
+func downloadRosters(tournamentID string) (*CachedRosters, error) {
+	filePath, err := DownloadChessResultsRosters(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer CleanupTempFile(filePath)
+
+	result, err := ParseChessResultsRosters(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rosters: %v", err)
+	}
+
+	logger.Info("Downloaded rosters for tournament %s: %d teams (warnings: %d)",
+		tournamentID, len(result.Rosters), len(result.Warnings))
+
+	return &CachedRosters{
+		TournamentID: tournamentID,
+		Result:       result,
+		FetchedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,205 @@
+package excel
+
+import (
+	"strings"
+)
+
+// Column names used in ColumnMap and reported in Dialect.Columns.
+const (
+	ColumnNumber      = "number"
+	ColumnHomeTeam    = "homeTeam"
+	ColumnGuestTeam   = "guestTeam"
+	ColumnHomeResult  = "homeResult"
+	ColumnGuestResult = "guestResult"
+	ColumnDate        = "date"
+	ColumnTime        = "time"
+	ColumnLocation    = "location"
+)
+
+// Layouts reported in Dialect.Layout.
+const (
+	LayoutHeader     = "header"     // Columns were mapped by the header row
+	LayoutPositional = "positional" // No header row was found, fixed legacy positions were used
+)
+
+// Dialect describes the flavour of a chess-results export as detected by the parser.
+type Dialect struct {
+	Layout   string         `json:"layout"`   // LayoutHeader or LayoutPositional
+	Language string         `json:"language"` // Language of the header row ("en", "sk", "cs", "de"), empty when unknown
+	Columns  map[string]int `json:"columns"`  // Detected column indexes by column name
+}
+
+// headerLanguage lists the header cell names chess-results uses in one language.
+type headerLanguage struct {
+	Code     string
+	Number   []string
+	Team     []string
+	Result   []string
+	Date     []string
+	Time     []string
+	Location []string
+}
+
+// headerLanguages are the localized header names recognised by the parser.
+var headerLanguages = []headerLanguage{
+	{
+		Code:     "en",
+		Number:   []string{"No.", "Bo."},
+		Team:     []string{"Team"},
+		Result:   []string{"Res.", "Result"},
+		Date:     []string{"Date"},
+		Time:     []string{"Time"},
+		Location: []string{"Location", "Venue", "Place"},
+	},
+	{
+		Code:     "sk",
+		Number:   []string{"Č.", "Por."},
+		Team:     []string{"Družstvo", "Tím"},
+		Result:   []string{"Výs.", "Výsledok"},
+		Date:     []string{"Dátum"},
+		Time:     []string{"Čas"},
+		Location: []string{"Miesto", "Miesto konania"},
+	},
+	{
+		Code:     "cs",
+		Number:   []string{"Č.", "Poř."},
+		Team:     []string{"Družstvo", "Tým"},
+		Result:   []string{"Výsl.", "Výsledek"},
+		Date:     []string{"Datum"},
+		Time:     []string{"Čas"},
+		Location: []string{"Místo", "Místo konání"},
+	},
+	{
+		Code:     "de",
+		Number:   []string{"Nr."},
+		Team:     []string{"Mannschaft", "Team"},
+		Result:   []string{"Erg.", "Ergebnis"},
+		Date:     []string{"Datum"},
+		Time:     []string{"Zeit", "Uhrzeit"},
+		Location: []string{"Ort", "Spielort"},
+	},
+}
+
+// ColumnMap holds the column index of every known column, -1 when the column is not present.
+type ColumnMap struct {
+	Number      int
+	HomeTeam    int
+	GuestTeam   int
+	HomeResult  int
+	GuestResult int
+	Date        int
+	Time        int
+	Location    int
+}
+
+// positionalColumns is the fixed layout used before header detection existed:
+// [No.] [HomeTeam] [GuestTeam] [Res1] [:] [Res2] [Date] [Time] [Location]
+var positionalColumns = ColumnMap{
+	Number:      0,
+	HomeTeam:    1,
+	GuestTeam:   2,
+	HomeResult:  3,
+	GuestResult: 5,
+	Date:        6,
+	Time:        7,
+	Location:    8,
+}
+
+// emptyColumnMap returns a ColumnMap without any columns.
+func emptyColumnMap() ColumnMap {
+	return ColumnMap{-1, -1, -1, -1, -1, -1, -1, -1}
+}
+
+// AsMap returns the detected columns keyed by column name, leaving out missing ones.
+func (m ColumnMap) AsMap() map[string]int {
+	result := make(map[string]int)
+	for name, index := range map[string]int{
+		ColumnNumber:      m.Number,
+		ColumnHomeTeam:    m.HomeTeam,
+		ColumnGuestTeam:   m.GuestTeam,
+		ColumnHomeResult:  m.HomeResult,
+		ColumnGuestResult: m.GuestResult,
+		ColumnDate:        m.Date,
+		ColumnTime:        m.Time,
+		ColumnLocation:    m.Location,
+	} {
+		if index >= 0 {
+			result[name] = index
+		}
+	}
+	return result
+}
+
+// cell returns the trimmed value at index, or "" when the column is missing or the row is too short.
+func cell(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+// matchesAny reports whether value equals one of the names (case-insensitive, surrounding spaces ignored).
+func matchesAny(value string, names []string) bool {
+	value = strings.TrimSpace(value)
+	for _, name := range names {
+		if strings.EqualFold(value, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectHeaderRow checks whether row is a pairing table header and maps its columns.
+// A header needs two team columns; the language whose names match the most cells wins.
+// Returns the column map, the language code and true when the row is a header.
+func detectHeaderRow(row []string) (ColumnMap, string, bool) {
+	bestScore := 0
+	bestColumns := emptyColumnMap()
+	bestLanguage := ""
+
+	for _, language := range headerLanguages {
+		columns := emptyColumnMap()
+		score := 0
+
+		for i, value := range row {
+			switch {
+			case matchesAny(value, language.Team):
+				if columns.HomeTeam < 0 {
+					columns.HomeTeam = i
+				} else if columns.GuestTeam < 0 {
+					columns.GuestTeam = i
+				}
+			case matchesAny(value, language.Number):
+				if columns.Number < 0 {
+					columns.Number = i
+				}
+			case matchesAny(value, language.Result):
+				if columns.HomeResult < 0 {
+					columns.HomeResult = i
+				} else if columns.GuestResult < 0 {
+					columns.GuestResult = i
+				}
+			case matchesAny(value, language.Date):
+				columns.Date = i
+			case matchesAny(value, language.Time):
+				columns.Time = i
+			case matchesAny(value, language.Location):
+				columns.Location = i
+			default:
+				continue
+			}
+			score++
+		}
+
+		if columns.HomeTeam < 0 || columns.GuestTeam < 0 {
+			continue
+		}
+		if score > bestScore {
+			bestScore = score
+			bestColumns = columns
+			bestLanguage = language.Code
+		}
+	}
+
+	return bestColumns, bestLanguage, bestScore > 0
+}
@@ -18,16 +18,26 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
+// TempDir holds downloaded and uploaded Excel files until they are parsed.
+const TempDir = "assets/tempfiles/"
+
 // DownloadChessResultsExcel downloads an Excel file from chess-results.com for the given tournament ID.
 // It constructs the appropriate URL and downloads the file to a temporary location.
 // The file is saved with a timestamp to avoid conflicts.
+// Downloads always request the English export (lan=1); localized exports are only expected from uploaded files.
 // Returns the file path of the downloaded Excel file or an error if the download fails.
 func DownloadChessResultsExcel(tournamentID string) (string, error) {
 	// Construct the URL for the Excel download
 	url := fmt.Sprintf("https://chess-results.com/tnr%s.aspx?lan=1&zeilen=0&art=2&prt=4&excel=2010", tournamentID)
+	fileName := fmt.Sprintf("chess_results_%s_%d.xlsx", tournamentID, time.Now().Unix())
 
 	logger.Debug("Downloading Excel for tournament ID: %s from %s", tournamentID, url)
+	return downloadExcel(url, fileName)
+}
 
+// downloadExcel downloads a chess-results Excel export from url into TempDir under fileName.
+// Returns the file path of the downloaded file.
+func downloadExcel(url, fileName string) (string, error) {
 	// Create HTTP client with timeout
 	client := &http.Client{
 		Timeout: 60 * time.Second,
@@ -46,13 +56,12 @@ func DownloadChessResultsExcel(tournamentID string) (string, error) {
 	}
 
 	// Create a dedicated directory for Excel files
-	excelDir := "assets/tempfiles/"
+	excelDir := TempDir
 	if err := os.MkdirAll(excelDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create downloads directory: %v", err)
 	}
 
 	// Create a permanent file to store the Excel data
-	fileName := fmt.Sprintf("chess_results_%s_%d.xlsx", tournamentID, time.Now().Unix())
 	filePath := filepath.Join(excelDir, fileName)
 
 	// Create the file
@@ -104,7 +113,7 @@ func ExtractTournamentIDFromLeague(league *data.League) (string, error) {
 
 // DownloadExcelForLeague downloads Excel file for a given league
 func DownloadExcelForLeague(league *data.League) (string, error) {
-	logger.Debug("Downloading Excel for league '%s' (ID: %d)", league.LeagueName, league.LeagueId)
+	logger.Debug("Downloading Excel for league '%s' (ID: %s)", league.LeagueName, league.LeagueId)
 
 	// Extract tournament ID from league's ChessResultsLink
 	tournamentID, err := ExtractTournamentIDFromLeague(league)
@@ -127,8 +136,28 @@ func isNumeric(s string) bool {
 	return err == nil
 }
 
+// ParseResult is the outcome of parsing a chess-results schedule export.
+type ParseResult struct {
+	Rounds     []data.Round            `json:"rounds"`     // Parsed rounds with their matches
+	Dialect    Dialect                 `json:"dialect"`    // Detected layout of the export
+	Warnings   []ParseWarning          `json:"warnings"`   // Rows that were skipped or look suspicious
+	Tournament TournamentInfo          `json:"tournament"` // Metadata from the header block above the first round
+	Undecided  []data.UndecidedPairing `json:"undecided"`  // Skipped pairing rows with a missing team, kept for placeholder fixtures
+}
+
 // ParseChessResultsExcelToRounds parses an Excel file and returns rounds with matches
 func ParseChessResultsExcelToRounds(filePath string) ([]data.Round, error) {
+	result, err := ParseChessResultsExcel(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return result.Rounds, nil
+}
+
+// ParseChessResultsExcel parses an Excel file and returns rounds with matches together with the detected dialect.
+// Columns are located by the pairing table header row (in any of the known languages);
+// the fixed legacy positions are only used while no header row has been seen.
+func ParseChessResultsExcel(filePath string) (*ParseResult, error) {
 	// Open the Excel file
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
@@ -151,99 +180,179 @@ func ParseChessResultsExcelToRounds(filePath string) ([]data.Round, error) {
 		return nil, fmt.Errorf("failed to get rows from sheet: %v", err)
 	}
 
+	result := parseScheduleRows(sheetName, rows)
+
+	for _, warning := range result.Warnings {
+		logger.Debug("Parser warning: %s", warning)
+	}
+	logger.Debug("Parsed %d rounds from Excel file %s (layout: %s, language: %s, warnings: %d)",
+		len(result.Rounds), filePath, result.Dialect.Layout, result.Dialect.Language, len(result.Warnings))
+	return result, nil
+}
+
+// parseScheduleRows turns the rows of a chess-results pairing export into rounds.
+// The header block above the first round is read into the tournament metadata.
+// Rows that are skipped or cannot be interpreted with certainty are reported as warnings;
+// the tournament header block produces no warnings unless it contains match rows.
+func parseScheduleRows(sheetName string, rows [][]string) *ParseResult {
 	var rounds []data.Round
 	var currentRound *data.Round
+	warnings := []ParseWarning{}
+	undecided := []data.UndecidedPairing{}
+
+	columns := positionalColumns
+	dialect := Dialect{Layout: LayoutPositional, Columns: positionalColumns.AsMap()}
+	headerSeen := false
+	roundLanguage := ""
+	var tournament TournamentInfo
 
-	for _, row := range rows {
-		if len(row) == 0 {
+	for i, row := range rows {
+		if isBlankRow(row) {
 			continue
 		}
 
-		// Check if this is a round header
+		// Check if this is a round header, in any of the known languages
 		// Format 1: "Round 1" (simple format - date/time from match rows)
 		// Format 2: "Round 1 on 2025/10/25 at 11:00" (date/time embedded in header)
-		if len(row) == 1 && strings.HasPrefix(row[0], "Round ") {
+		if len(row) == 1 && looksLikeRoundHeader(row[0]) {
+			header, ok := parseRoundHeader(row[0])
+			if !ok {
+				// Keep collecting matches into the current round, the header is reported instead
+				warnings = append(warnings, newParseWarning(sheetName, i, row, WarningUnknownRound,
+					"round header does not match any known format, following matches are kept in the previous round"))
+				continue
+			}
+
 			// If we have a previous round, add it to the rounds slice
 			if currentRound != nil {
 				rounds = append(rounds, *currentRound)
 			}
 
-			// Try Format 2 first: "Round N on YYYY/MM/DD at HH:MM"
-			reWithDate := regexp.MustCompile(`Round (\d+) on (\d{4}/\d{2}/\d{2}) at (\d{2}:\d{2})`)
-			matchesWithDate := reWithDate.FindStringSubmatch(row[0])
-
-			if len(matchesWithDate) >= 4 {
-				// Format 2: Extract round number and date/time from header
-				roundNumber, _ := strconv.Atoi(matchesWithDate[1])
-				dateTime := fmt.Sprintf("%s %s", matchesWithDate[2], matchesWithDate[3])
-
-				currentRound = &data.Round{
-					Number:   roundNumber,
-					DateTime: dateTime,
-					Matches:  []data.MatchInfo{},
-				}
-			} else {
-				// Format 1: Extract only round number, date/time will come from match rows
-				reSimple := regexp.MustCompile(`Round (\d+)`)
-				matchesSimple := reSimple.FindStringSubmatch(row[0])
-
-				if len(matchesSimple) >= 2 {
-					roundNumber, _ := strconv.Atoi(matchesSimple[1])
-
-					currentRound = &data.Round{
-						Number:   roundNumber,
-						DateTime: "", // Will be set from first match
-						Matches:  []data.MatchInfo{},
-					}
-				}
+			// Format 1 leaves the date/time empty, it will be set from the first match
+			dateTime := ""
+			if header.Date != "" {
+				dateTime = strings.TrimSpace(fmt.Sprintf("%s %s", header.Date, header.Time))
+			}
+
+			currentRound = &data.Round{
+				Number:   header.Number,
+				DateTime: dateTime,
+				Matches:  []data.MatchInfo{},
+			}
+			if roundLanguage == "" {
+				roundLanguage = header.Language
 			}
 			continue
 		}
 
-		// Check if this is the column header row (skip it)
-		if len(row) >= 3 && row[0] == "No." && row[1] == "Team" && row[2] == "Team" {
+		// Check if this is the column header row - map the columns by name and skip it
+		if headerColumns, language, ok := detectHeaderRow(row); ok {
+			columns = headerColumns
+			if !headerSeen {
+				headerSeen = true
+				dialect = Dialect{Layout: LayoutHeader, Language: language, Columns: columns.AsMap()}
+			}
 			continue
 		}
 
 		// Check if this is a match row
-		// Format: [No.] [HomeTeam] [GuestTeam] [Res1] [:] [Res2] [Date] [Time] [Location]
-		// Need at least 3 columns (some formats may not have all 9 columns)
-		if len(row) >= 3 && isNumeric(row[0]) && row[1] != "" && row[2] != "" {
-			// Only add match if we have a current round
-			if currentRound != nil {
-				var dateTime string
-				var address string
-
-				// Check if we have date/time in columns (Format 1)
-				if len(row) >= 9 {
-					// Extract date and time from columns 6 and 7
-					date := strings.TrimSpace(row[6])    // Format: YYYY/MM/DD
-					timeStr := strings.TrimSpace(row[7]) // Format: HH:MM
-					address = strings.TrimSpace(row[8])  // Location
-
-					// Combine date and time
-					dateTime = fmt.Sprintf("%s %s", date, timeStr)
-
-					// Set round's DateTime from first match if not set (Format 1)
-					if currentRound.DateTime == "" {
-						currentRound.DateTime = dateTime
-					}
-				} else {
-					// Use round's DateTime (Format 2 - date/time from header)
-					dateTime = currentRound.DateTime
-					address = "" // Address not available in this format
-				}
-
-				match := data.MatchInfo{
-					HomeTeam:  strings.TrimSpace(row[1]),
-					GuestTeam: strings.TrimSpace(row[2]),
-					DateTime:  dateTime,
-					Address:   address,
-				}
-
-				currentRound.Matches = append(currentRound.Matches, match)
+		// Need the pairing number (when the export has one) and both team names
+		homeTeam := cell(row, columns.HomeTeam)
+		guestTeam := cell(row, columns.GuestTeam)
+		numbered := columns.Number < 0 || isNumeric(cell(row, columns.Number))
+		missingTeam := false
+		if homeTeam == "" || guestTeam == "" {
+			// Rows above the first round are the tournament header block
+			if currentRound == nil {
+				tournament.parseTournamentRow(row)
+				continue
+			}
+			if len(row) < minMatchColumns {
+				warnings = append(warnings, newParseWarning(sheetName, i, row, WarningTooFewColumns,
+					fmt.Sprintf("row has %d columns, a match row needs at least %d", len(row), minMatchColumns)))
+				continue
+			}
+			if columns.Number < 0 || !numbered {
+				continue
+			}
+			// Skipped, but kept aside: cup rounds list the pairing before the draw and a fixture may cover it
+			warnings = append(warnings, newParseWarning(sheetName, i, row, WarningMissingTeam,
+				"pairing row is missing a team name"))
+			missingTeam = true
+		}
+		if !numbered {
+			if currentRound == nil {
+				tournament.parseTournamentRow(row)
+			}
+			continue
+		}
+
+		// Only add match if we have a current round
+		if currentRound == nil {
+			warnings = append(warnings, newParseWarning(sheetName, i, row, WarningMatchBeforeRound,
+				"match row appears before any round header and was skipped"))
+			continue
+		}
+
+		// Without a header the date/time/location positions are only trusted on full-width rows
+		rowColumns := columns
+		if !headerSeen && len(row) <= positionalColumns.Location {
+			rowColumns.Date, rowColumns.Time, rowColumns.Location = -1, -1, -1
+		}
+
+		var dateTime string
+		var address string
+
+		date := normalizeDate(cell(row, rowColumns.Date))    // Format: YYYY/MM/DD
+		timeStr := normalizeTime(cell(row, rowColumns.Time)) // Format: HH:MM
+		if date != "" || timeStr != "" {
+			// Date/time in columns (Format 1)
+			dateTime = strings.TrimSpace(fmt.Sprintf("%s %s", date, timeStr))
+
+			// Set round's DateTime from first match if not set (Format 1)
+			if currentRound.DateTime == "" && !missingTeam {
+				currentRound.DateTime = dateTime
+			}
+		} else {
+			// Use round's DateTime (Format 2 - date/time from header)
+			dateTime = currentRound.DateTime
+		}
+		address = cell(row, rowColumns.Location)
+
+		// The pairing number identifies the match within the round; exports without it fall back to the row order
+		pairing, err := strconv.Atoi(cell(row, columns.Number))
+		if err != nil {
+			pairing = len(currentRound.Matches) + 1
+		}
+
+		match := data.MatchInfo{
+			Pairing:   pairing,
+			HomeTeam:  homeTeam,
+			GuestTeam: guestTeam,
+			DateTime:  dateTime,
+			Address:   address,
+		}
+		if missingTeam {
+			undecided = append(undecided, data.UndecidedPairing{Round: currentRound.Number, Match: match})
+			continue
+		}
+		var resultOK bool
+		match.HomeResult, match.GuestResult, match.Played, resultOK = parseResult(
+			cell(row, columns.HomeResult), cell(row, columns.GuestResult))
+		if !resultOK {
+			warnings = append(warnings, newParseWarning(sheetName, i, row, WarningInvalidResult,
+				"result could not be read, the match is treated as not played yet"))
+		}
+		match.StartsAt, match.DateTimeError = ParseScheduleDateTime(dateTime)
+		if match.DateTimeError != "" {
+			reason := WarningInvalidDateTime
+			if dateTime == "" {
+				reason = WarningMissingDateTime
 			}
+			warnings = append(warnings, newParseWarning(sheetName, i, row, reason, match.DateTimeError))
 		}
+
+		currentRound.Matches = append(currentRound.Matches, match)
 	}
 
 	// Add the last round if it exists
@@ -251,12 +360,42 @@ func ParseChessResultsExcelToRounds(filePath string) ([]data.Round, error) {
 		rounds = append(rounds, *currentRound)
 	}
 
-	logger.Debug("Parsed %d rounds from Excel file %s", len(rounds), filePath)
-	return rounds, nil
+	// Exports without a location column (date in the round header) leave the addresses empty;
+	// the app fills them from the venue directory and then from the tournament location
+	for i := range rounds {
+		rounds[i].StartsAt, rounds[i].DateTimeError = ParseScheduleDateTime(rounds[i].DateTime)
+		rounds[i].UpdateStatus()
+	}
+
+	// Exports without a pairing header still reveal their language through the round headers
+	if dialect.Language == "" {
+		dialect.Language = roundLanguage
+	}
+
+	return &ParseResult{Rounds: rounds, Dialect: dialect, Warnings: warnings, Tournament: tournament, Undecided: undecided}
+}
+
+// ParseScheduleDateTime parses a schedule date/time in the Europe/Bratislava zone.
+// Returns nil and the reason when the value is missing or invalid.
+func ParseScheduleDateTime(value string) (*time.Time, string) {
+	startsAt, err := data.ParseDateTime(value)
+	if err != nil {
+		return nil, err.Error()
+	}
+	return &startsAt, ""
 }
 
 // ParseExcelForLeagueToRounds downloads and parses Excel file for a given league, returning rounds
 func ParseExcelForLeagueToRounds(league *data.League) ([]data.Round, error) {
+	result, err := ParseExcelForLeague(league)
+	if err != nil {
+		return nil, err
+	}
+	return result.Rounds, nil
+}
+
+// ParseExcelForLeague downloads and parses Excel file for a given league, returning rounds and the detected dialect
+func ParseExcelForLeague(league *data.League) (*ParseResult, error) {
 	// Download the Excel file
 	filePath, err := DownloadExcelForLeague(league)
 	if err != nil {
@@ -264,7 +403,7 @@ func ParseExcelForLeagueToRounds(league *data.League) ([]data.Round, error) {
 	}
 
 	// Parse the Excel file
-	rounds, err := ParseChessResultsExcelToRounds(filePath)
+	result, err := ParseChessResultsExcel(filePath)
 	if err != nil {
 		// Clean up Excel file even if parsing fails
 		CleanupTempFile(filePath)
@@ -276,6 +415,10 @@ func ParseExcelForLeagueToRounds(league *data.League) ([]data.Round, error) {
 		logger.Error("Failed to cleanup Excel file %s: %v", filePath, err)
 	}
 
-	logger.Info("Parsed %d rounds from Excel for league '%s'", len(rounds), league.LeagueName)
-	return rounds, nil
+	tournamentID, _ := ExtractTournamentIDFromLeague(league)
+	data.AssignMatchIDs(result.Rounds, tournamentID)
+
+	logger.Info("Parsed %d rounds from Excel for league '%s' (layout: %s, warnings: %d)",
+		len(result.Rounds), league.LeagueName, result.Dialect.Layout, len(result.Warnings))
+	return result, nil
 }
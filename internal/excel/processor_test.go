@@ -0,0 +1,237 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+)
+
+// parsedMatch is the part of a parsed match the schedule tests compare.
+type parsedMatch struct {
+	Round       int
+	Pairing     int
+	HomeTeam    string
+	GuestTeam   string
+	DateTime    string
+	Address     string
+	HomeResult  string
+	GuestResult string
+	Played      bool
+	Parsed      bool // StartsAt is set
+}
+
+// parsedMatches flattens the rounds of a parse result into comparable matches.
+func parsedMatches(result *ParseResult) []parsedMatch {
+	var matches []parsedMatch
+	for _, round := range result.Rounds {
+		for _, match := range round.Matches {
+			matches = append(matches, parsedMatch{
+				Round:       round.Number,
+				Pairing:     match.Pairing,
+				HomeTeam:    match.HomeTeam,
+				GuestTeam:   match.GuestTeam,
+				DateTime:    match.DateTime,
+				Address:     match.Address,
+				HomeResult:  match.HomeResult,
+				GuestResult: match.GuestResult,
+				Played:      match.Played,
+				Parsed:      match.StartsAt != nil,
+			})
+		}
+	}
+	return matches
+}
+
+// warningReasons returns the reasons of the warnings in order.
+func warningReasons(warnings []ParseWarning) []string {
+	reasons := []string{}
+	for _, warning := range warnings {
+		reasons = append(reasons, warning.Reason)
+	}
+	return reasons
+}
+
+func TestParseScheduleRows(t *testing.T) {
+	tests := []struct {
+		name          string
+		rows          [][]string
+		wantLayout    string
+		wantLanguage  string
+		wantMatches   []parsedMatch
+		wantWarnings  []string
+		wantUndecided int
+	}{
+		{
+			name: "english header with date in the round header",
+			rows: [][]string{
+				{"Round 1 on 2025/10/25 at 11:00"},
+				{"No.", "Team", "Team", "Res.", ":", "Res.", "Date", "Time", "Location"},
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica", "4½", ":", "3½"},
+				{"2", "TJ Slávia", "ŠK Modra"},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "en",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", DateTime: "2025/10/25 11:00",
+					HomeResult: "4.5", GuestResult: "3.5", Played: true, Parsed: true},
+				{Round: 1, Pairing: 2, HomeTeam: "TJ Slávia", GuestTeam: "ŠK Modra", DateTime: "2025/10/25 11:00", Parsed: true},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "slovak header with date columns",
+			rows: [][]string{
+				{"1. kolo"},
+				{"Č.", "Družstvo", "Družstvo", "Výs.", "", "Výs.", "Dátum", "Čas", "Miesto"},
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica", "", "", "", "25.10.2025", "9:00", "Telocvičňa ZŠ"},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "sk",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", DateTime: "2025/10/25 09:00",
+					Address: "Telocvičňa ZŠ", Parsed: true},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "czech header",
+			rows: [][]string{
+				{"Kolo 1"},
+				{"Č.", "Tým", "Tým", "Výsl.", "", "Výsl.", "Datum", "Čas", "Místo"},
+				{"1", "TJ Bohemians", "ŠK Ostrava", "+", "", "-", "25.10.2025", "10:00", "Praha"},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "cs",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "TJ Bohemians", GuestTeam: "ŠK Ostrava", DateTime: "2025/10/25 10:00",
+					Address: "Praha", HomeResult: "+", GuestResult: "-", Played: true, Parsed: true},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "german header with both scores in one cell",
+			rows: [][]string{
+				{"Runde 1 am 25.10.2025 um 11:00"},
+				{"Nr.", "Mannschaft", "Mannschaft", "Erg.", "", "Erg."},
+				{"1", "SK Wien", "SC Graz", "5 : 3"},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "de",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "SK Wien", GuestTeam: "SC Graz", DateTime: "2025/10/25 11:00",
+					HomeResult: "5", GuestResult: "3", Played: true, Parsed: true},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "positional layout takes the language from the round header",
+			rows: [][]string{
+				{"Round 1"},
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica", "", "", "", "2025/10/25", "11:00", "Hall"},
+			},
+			wantLayout:   LayoutPositional,
+			wantLanguage: "en",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", DateTime: "2025/10/25 11:00",
+					Address: "Hall", Parsed: true},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "undecided pairing row is kept aside",
+			rows: [][]string{
+				{"Round 1 on 2025/10/25 at 11:00"},
+				{"No.", "Team", "Team", "Res.", ":", "Res."},
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica"},
+				{"2", "ŠK Modra", ""},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "en",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", DateTime: "2025/10/25 11:00", Parsed: true},
+			},
+			wantWarnings:  []string{WarningMissingTeam},
+			wantUndecided: 1,
+		},
+		{
+			name: "invalid result",
+			rows: [][]string{
+				{"Round 1 on 2025/10/25 at 11:00"},
+				{"No.", "Team", "Team", "Res.", ":", "Res."},
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica", "x", ":", "y"},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "en",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", DateTime: "2025/10/25 11:00", Parsed: true},
+			},
+			wantWarnings: []string{WarningInvalidResult},
+		},
+		{
+			name: "invalid date",
+			rows: [][]string{
+				{"Round 1"},
+				{"No.", "Team", "Team", "Date", "Time"},
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica", "2025/13/45", "11:00"},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "en",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", DateTime: "2025/13/45 11:00"},
+			},
+			wantWarnings: []string{WarningInvalidDateTime},
+		},
+		{
+			name: "missing date",
+			rows: [][]string{
+				{"Round 1"},
+				{"No.", "Team", "Team"},
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica"},
+			},
+			wantLayout:   LayoutHeader,
+			wantLanguage: "en",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica"},
+			},
+			wantWarnings: []string{WarningMissingDateTime},
+		},
+		{
+			name: "match before the first round and unknown round header",
+			rows: [][]string{
+				{"1", "ŠK Prievidza", "ŠKŠ Dubnica"},
+				{"Round 1 on 2025/10/25 at 11:00"},
+				{"1", "TJ Slávia", "ŠK Modra"},
+				{"Round two"},
+				{"2", "ŠK Modra", "TJ Slávia"},
+			},
+			wantLayout:   LayoutPositional,
+			wantLanguage: "en",
+			wantMatches: []parsedMatch{
+				{Round: 1, Pairing: 1, HomeTeam: "TJ Slávia", GuestTeam: "ŠK Modra", DateTime: "2025/10/25 11:00", Parsed: true},
+				{Round: 1, Pairing: 2, HomeTeam: "ŠK Modra", GuestTeam: "TJ Slávia", DateTime: "2025/10/25 11:00", Parsed: true},
+			},
+			wantWarnings: []string{WarningMatchBeforeRound, WarningUnknownRound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseScheduleRows("Sheet1", tt.rows)
+
+			if result.Dialect.Layout != tt.wantLayout {
+				t.Errorf("layout = %q, want %q", result.Dialect.Layout, tt.wantLayout)
+			}
+			if result.Dialect.Language != tt.wantLanguage {
+				t.Errorf("language = %q, want %q", result.Dialect.Language, tt.wantLanguage)
+			}
+			if got := parsedMatches(result); !reflect.DeepEqual(got, tt.wantMatches) {
+				t.Errorf("matches = %+v, want %+v", got, tt.wantMatches)
+			}
+			if got := warningReasons(result.Warnings); !reflect.DeepEqual(got, tt.wantWarnings) {
+				t.Errorf("warnings = %v, want %v", got, tt.wantWarnings)
+			}
+			if len(result.Undecided) != tt.wantUndecided {
+				t.Errorf("undecided = %d, want %d", len(result.Undecided), tt.wantUndecided)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package excel
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Forfeit scores as chess-results prints them ("+ : -" when the guest team did not show up).
+const (
+	forfeitWin  = "+"
+	forfeitLoss = "-"
+)
+
+// combinedResult matches both scores in a single cell, e.g. "4½ : 3½" or "5-3".
+var combinedResult = regexp.MustCompile(`^\s*([0-9½.,+-]+)\s*[:–-]\s*([0-9½.,+-]+)\s*$`)
+
+// normalizeScore converts a team score to a plain decimal ("4½" -> "4.5", "4,5" -> "4.5").
+// Forfeits are kept as "+" and "-". Returns false when the value is not a score.
+func normalizeScore(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == forfeitWin || value == forfeitLoss {
+		return value, true
+	}
+
+	value = strings.ReplaceAll(value, ",", ".")
+	if strings.HasSuffix(value, "½") {
+		whole := strings.TrimSuffix(value, "½")
+		if whole == "" {
+			whole = "0"
+		}
+		value = whole + ".5"
+	}
+
+	score, err := strconv.ParseFloat(value, 64)
+	if err != nil || score < 0 {
+		return "", false
+	}
+	return strconv.FormatFloat(score, 'f', -1, 64), true
+}
+
+// parseResult reads the result of a match from its home and guest result cells.
+// Some exports put both scores into the home cell, the guest cell is then empty.
+// Returns the normalized scores and whether the match has a result; empty cells mean the match
+// has not been played yet, and ok is false when the cells hold something that is not a result.
+func parseResult(home, guest string) (homeResult, guestResult string, played, ok bool) {
+	home, guest = strings.TrimSpace(home), strings.TrimSpace(guest)
+	if home == "" && guest == "" {
+		return "", "", false, true
+	}
+
+	if guest == "" {
+		parts := combinedResult.FindStringSubmatch(home)
+		if parts == nil {
+			return "", "", false, false
+		}
+		home, guest = parts[1], parts[2]
+	}
+
+	homeResult, homeOK := normalizeScore(home)
+	guestResult, guestOK := normalizeScore(guest)
+	if !homeOK || !guestOK {
+		return "", "", false, false
+	}
+	return homeResult, guestResult, true, true
+}
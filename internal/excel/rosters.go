@@ -0,0 +1,246 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"github.com/xuri/excelize/v2"
+)
+
+// WarningPlayerBeforeTeam is reported for player rows above the first team heading.
+const WarningPlayerBeforeTeam = "player_before_team"
+
+// rosterLanguage lists the roster header cell names chess-results uses in one language.
+type rosterLanguage struct {
+	Code       string
+	Board      []string
+	Title      []string
+	Name       []string
+	PlayerID   []string
+	FideID     []string
+	Rating     []string
+	Federation []string
+}
+
+// rosterLanguages are the localized roster header names recognised by the parser.
+var rosterLanguages = []rosterLanguage{
+	{
+		Code:       "en",
+		Board:      []string{"Bo.", "Brd."},
+		Title:      []string{"Title"},
+		Name:       []string{"Name"},
+		PlayerID:   []string{"ID", "NatID"},
+		FideID:     []string{"FideID", "FIDE-ID"},
+		Rating:     []string{"Rtg", "RtgI", "Elo"},
+		Federation: []string{"FED", "Fed."},
+	},
+	{
+		Code:       "sk",
+		Board:      []string{"Š.", "Šach."},
+		Title:      []string{"Titul"},
+		Name:       []string{"Meno"},
+		PlayerID:   []string{"ID", "NatID"},
+		FideID:     []string{"FideID", "FIDE ID"},
+		Rating:     []string{"Elo", "Rtg"},
+		Federation: []string{"FED", "Fed."},
+	},
+	{
+		Code:       "cs",
+		Board:      []string{"Š.", "Šach."},
+		Title:      []string{"Titul"},
+		Name:       []string{"Jméno"},
+		PlayerID:   []string{"ID", "NatID"},
+		FideID:     []string{"FideID", "FIDE ID"},
+		Rating:     []string{"Elo", "Rtg"},
+		Federation: []string{"FED", "Fed."},
+	},
+	{
+		Code:       "de",
+		Board:      []string{"Br.", "Brett"},
+		Title:      []string{"Titel"},
+		Name:       []string{"Name"},
+		PlayerID:   []string{"ID", "NatID"},
+		FideID:     []string{"FideID", "FIDE-ID"},
+		Rating:     []string{"Elo", "Rtg"},
+		Federation: []string{"Land", "FED"},
+	},
+}
+
+// rosterColumns holds the column index of every roster column, -1 when the column is not present.
+type rosterColumns struct {
+	Board, Title, Name, PlayerID, FideID, Rating, Federation int
+}
+
+// teamHeading matches a team heading such as "3. ŠKŠ Dubnica B (RtgAvg:2105, Captain: ...)".
+// Titles such as "1. liga 2025/2026" match as well, so a heading only counts when a roster header row follows it.
+var teamHeading = regexp.MustCompile(`^(\d+)\.\s+(.+?)(?:\s*\(.*\))?$`)
+
+// RosterResult is the outcome of parsing a chess-results team composition export.
+type RosterResult struct {
+	Rosters  data.Rosters   `json:"rosters"`  // Teams with their players in board order
+	Warnings []ParseWarning `json:"warnings"` // Rows that were skipped
+}
+
+// DownloadChessResultsRosters downloads the team composition export of a tournament (English, lan=1).
+// Returns the file path of the downloaded Excel file.
+func DownloadChessResultsRosters(tournamentID string) (string, error) {
+	url := fmt.Sprintf("https://chess-results.com/tnr%s.aspx?lan=1&zeilen=99999&art=16&excel=2010", tournamentID)
+	fileName := fmt.Sprintf("chess_results_rosters_%s_%d.xlsx", tournamentID, time.Now().Unix())
+
+	logger.Debug("Downloading rosters for tournament ID: %s from %s", tournamentID, url)
+	return downloadExcel(url, fileName)
+}
+
+// ParseChessResultsRosters parses a team composition export into team rosters.
+func ParseChessResultsRosters(filePath string) (*RosterResult, error) {
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	sheetName := f.GetSheetName(0)
+	if sheetName == "" {
+		return nil, fmt.Errorf("no sheets found in Excel file")
+	}
+
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from sheet: %v", err)
+	}
+
+	result := parseRosterRows(sheetName, rows)
+	logger.Debug("Parsed %d team rosters from Excel file %s (warnings: %d)", len(result.Rosters), filePath, len(result.Warnings))
+	return result, nil
+}
+
+// parseRosterRows turns the rows of a team composition export into rosters.
+// Every team starts with a heading row ("1. Team name") followed by a header row and one row per player.
+// Rows above the first team heading (the tournament header block) are ignored.
+func parseRosterRows(sheetName string, rows [][]string) *RosterResult {
+	rosters := data.Rosters{}
+	warnings := []ParseWarning{}
+	var current *data.TeamRoster
+	columns := rosterColumns{-1, -1, -1, -1, -1, -1, -1}
+
+	for i, row := range rows {
+		if isBlankRow(row) {
+			continue
+		}
+
+		if headerColumns, ok := detectRosterHeader(row); ok {
+			columns = headerColumns
+			continue
+		}
+
+		if rank, team, ok := parseTeamHeading(row); ok && followedByRosterHeader(rows, i) {
+			rosters = append(rosters, data.TeamRoster{StartingRank: rank, Team: team, Players: []data.RosterPlayer{}})
+			current = &rosters[len(rosters)-1]
+			continue
+		}
+
+		if columns.Name < 0 {
+			continue
+		}
+		board, err := strconv.Atoi(cell(row, columns.Board))
+		name := cell(row, columns.Name)
+		if err != nil || name == "" {
+			continue
+		}
+		if current == nil {
+			warnings = append(warnings, newParseWarning(sheetName, i, row, WarningPlayerBeforeTeam,
+				"player row appears before any team heading and was skipped"))
+			continue
+		}
+
+		rating, _ := strconv.Atoi(cell(row, columns.Rating))
+		fideID := cell(row, columns.FideID)
+		if fideID == "0" {
+			fideID = ""
+		}
+		current.Players = append(current.Players, data.RosterPlayer{
+			Board:      board,
+			Title:      cell(row, columns.Title),
+			Name:       name,
+			PlayerID:   cell(row, columns.PlayerID),
+			FideID:     fideID,
+			Rating:     rating,
+			Federation: cell(row, columns.Federation),
+		})
+	}
+
+	return &RosterResult{Rosters: rosters, Warnings: warnings}
+}
+
+// followedByRosterHeader reports whether the next non-blank row after index is a roster header row.
+func followedByRosterHeader(rows [][]string, index int) bool {
+	for _, row := range rows[index+1:] {
+		if isBlankRow(row) {
+			continue
+		}
+		_, ok := detectRosterHeader(row)
+		return ok
+	}
+	return false
+}
+
+// parseTeamHeading reads the starting rank and team name from a team heading row.
+// A heading has a single filled cell; the rating average and captain in parentheses are dropped.
+func parseTeamHeading(row []string) (int, string, bool) {
+	var text string
+	for _, value := range row {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if text != "" {
+			return 0, "", false
+		}
+		text = strings.TrimSpace(value)
+	}
+
+	parts := teamHeading.FindStringSubmatch(text)
+	if parts == nil {
+		return 0, "", false
+	}
+	rank, _ := strconv.Atoi(parts[1])
+	return rank, strings.TrimSpace(parts[2]), true
+}
+
+// detectRosterHeader checks whether row is a roster header and maps its columns.
+// A header needs a board and a name column; an unnamed column right before the name holds the title.
+func detectRosterHeader(row []string) (rosterColumns, bool) {
+	for _, language := range rosterLanguages {
+		columns := rosterColumns{-1, -1, -1, -1, -1, -1, -1}
+		for i, value := range row {
+			switch {
+			case matchesAny(value, language.Board) && columns.Board < 0:
+				columns.Board = i
+			case matchesAny(value, language.Title):
+				columns.Title = i
+			case matchesAny(value, language.Name) && columns.Name < 0:
+				columns.Name = i
+			case matchesAny(value, language.PlayerID):
+				columns.PlayerID = i
+			case matchesAny(value, language.FideID):
+				columns.FideID = i
+			case matchesAny(value, language.Rating) && columns.Rating < 0:
+				columns.Rating = i
+			case matchesAny(value, language.Federation):
+				columns.Federation = i
+			}
+		}
+		if columns.Board >= 0 && columns.Name >= 0 {
+			// chess-results leaves the title column before the name without a header
+			if columns.Title < 0 && columns.Name-1 > columns.Board && strings.TrimSpace(row[columns.Name-1]) == "" {
+				columns.Title = columns.Name - 1
+			}
+			return columns, true
+		}
+	}
+	return rosterColumns{}, false
+}
@@ -0,0 +1,120 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+func TestParseRosterRows(t *testing.T) {
+	tests := []struct {
+		name         string
+		rows         [][]string
+		want         data.Rosters
+		wantWarnings []string
+	}{
+		{
+			name: "english export with tournament header block",
+			rows: [][]string{
+				{"1. liga 2025/2026"},
+				{"Organizer: SŠZ"},
+				{},
+				{"1. ŠK Prievidza (RtgAvg:2105, Captain: Novák)"},
+				{"Bo.", "", "Name", "ID", "FideID", "Rtg", "FED"},
+				{"1", "GM", "Novák, Ján", "12345", "14800000", "2510", "SVK"},
+				{"2", "", "Kováč, Peter", "12346", "0", "0", "SVK"},
+				{"", "", "Average", "", "", "1255", ""},
+				{"3. ŠKŠ Dubnica B"},
+				{"Bo.", "", "Name", "ID", "FideID", "Rtg", "FED"},
+				{"1", "", "Horváth, Jozef", "", "", "1850", "SVK"},
+			},
+			want: data.Rosters{
+				{Team: "ŠK Prievidza", StartingRank: 1, Players: []data.RosterPlayer{
+					{Board: 1, Title: "GM", Name: "Novák, Ján", PlayerID: "12345", FideID: "14800000", Rating: 2510, Federation: "SVK"},
+					{Board: 2, Name: "Kováč, Peter", PlayerID: "12346", Federation: "SVK"},
+				}},
+				{Team: "ŠKŠ Dubnica B", StartingRank: 3, Players: []data.RosterPlayer{
+					{Board: 1, Name: "Horváth, Jozef", Rating: 1850, Federation: "SVK"},
+				}},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "slovak header",
+			rows: [][]string{
+				{"2. TJ Slávia Trnava"},
+				{"Š.", "Titul", "Meno", "ID", "FideID", "Elo", "FED"},
+				{"1", "FM", "Kráľ, Ivan", "222", "", "2300", "SVK"},
+			},
+			want: data.Rosters{
+				{Team: "TJ Slávia Trnava", StartingRank: 2, Players: []data.RosterPlayer{
+					{Board: 1, Title: "FM", Name: "Kráľ, Ivan", PlayerID: "222", Rating: 2300, Federation: "SVK"},
+				}},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "czech header",
+			rows: [][]string{
+				{"1. TJ Bohemians Praha"},
+				{"Š.", "Titul", "Jméno", "ID", "FideID", "Elo", "FED"},
+				{"1", "IM", "Dvořák, Petr", "333", "310000", "2420", "CZE"},
+			},
+			want: data.Rosters{
+				{Team: "TJ Bohemians Praha", StartingRank: 1, Players: []data.RosterPlayer{
+					{Board: 1, Title: "IM", Name: "Dvořák, Petr", PlayerID: "333", FideID: "310000", Rating: 2420, Federation: "CZE"},
+				}},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "german header",
+			rows: [][]string{
+				{"4. SK Wien"},
+				{"Br.", "Titel", "Name", "ID", "FIDE-ID", "Elo", "Land"},
+				{"1", "", "Huber, Max", "", "1600000", "2100", "AUT"},
+			},
+			want: data.Rosters{
+				{Team: "SK Wien", StartingRank: 4, Players: []data.RosterPlayer{
+					{Board: 1, Name: "Huber, Max", FideID: "1600000", Rating: 2100, Federation: "AUT"},
+				}},
+			},
+			wantWarnings: []string{},
+		},
+		{
+			name: "player before the first team heading",
+			rows: [][]string{
+				{"Bo.", "", "Name"},
+				{"1", "", "Novák, Ján"},
+				{"1. ŠK Modra"},
+				{"Bo.", "", "Name"},
+				{"1", "", "Kováč, Peter"},
+			},
+			want: data.Rosters{
+				{Team: "ŠK Modra", StartingRank: 1, Players: []data.RosterPlayer{
+					{Board: 1, Name: "Kováč, Peter"},
+				}},
+			},
+			wantWarnings: []string{WarningPlayerBeforeTeam},
+		},
+		{
+			name:         "no rosters",
+			rows:         [][]string{{"1. liga 2025/2026"}, {"Organizer: SŠZ"}},
+			want:         data.Rosters{},
+			wantWarnings: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseRosterRows("Sheet1", tt.rows)
+			if !reflect.DeepEqual(result.Rosters, tt.want) {
+				t.Errorf("rosters = %+v, want %+v", result.Rosters, tt.want)
+			}
+			if got := warningReasons(result.Warnings); !reflect.DeepEqual(got, tt.wantWarnings) {
+				t.Errorf("warnings = %v, want %v", got, tt.wantWarnings)
+			}
+		})
+	}
+}
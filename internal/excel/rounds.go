@@ -0,0 +1,159 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// roundLanguage lists the words chess-results uses in round headers in one language,
+// e.g. "Round 1 on 2025/10/25 at 11:00" or "Runde 1 am 25.10.2025 um 11:00".
+type roundLanguage struct {
+	Code  string
+	Round string   // Word for "Round"
+	On    []string // Word(s) between the round number and the date
+	At    []string // Word(s) between the date and the time
+}
+
+// roundLanguages are the localized round headers recognised by the parser.
+// Slovak and Czech share the word "Kolo", the connecting words tell them apart.
+var roundLanguages = []roundLanguage{
+	{Code: "en", Round: "Round", On: []string{"on"}, At: []string{"at"}},
+	{Code: "sk", Round: "Kolo", On: []string{"dňa", "dna"}, At: []string{"o"}},
+	{Code: "cs", Round: "Kolo", On: []string{"dne"}, At: []string{"v", "ve"}},
+	{Code: "de", Round: "Runde", On: []string{"am"}, At: []string{"um"}},
+}
+
+// datePattern matches the date formats used in the exports: 2025/10/25, 25.10.2025 and 25. 10. 2025.
+const datePattern = `\d{4}/\d{1,2}/\d{1,2}|\d{1,2}\.\s?\d{1,2}\.\s?\d{4}`
+
+// timePattern matches a time of day such as 11:00 or 9:30.
+const timePattern = `\d{1,2}:\d{2}`
+
+// roundPattern is a compiled round header format of one language.
+type roundPattern struct {
+	Language string
+	WithDate *regexp.Regexp // "Round N on DATE at TIME" (also "N. kolo dňa DATE o TIME")
+	Simple   *regexp.Regexp // "Round N" (also "N. kolo")
+}
+
+// roundPatterns are compiled from roundLanguages once at start-up.
+var roundPatterns = compileRoundPatterns(roundLanguages)
+
+// compileRoundPatterns builds the header regular expressions for every language.
+// Both "Kolo 1" and "1. kolo" word orders are accepted, case-insensitively.
+func compileRoundPatterns(languages []roundLanguage) []roundPattern {
+	var patterns []roundPattern
+	for _, language := range languages {
+		word := regexp.QuoteMeta(language.Round)
+		number := fmt.Sprintf(`(?:%s\s+(?P<round>\d+)|(?P<roundSuffix>\d+)\.\s*%s)`, word, word)
+		on := quoteAll(language.On)
+		at := quoteAll(language.At)
+
+		patterns = append(patterns, roundPattern{
+			Language: language.Code,
+			WithDate: regexp.MustCompile(fmt.Sprintf(`(?i)^%s\s+(?:%s)\s+(?P<date>%s)\s+(?:%s)\s+(?P<time>%s)`,
+				number, on, datePattern, at, timePattern)),
+			Simple: regexp.MustCompile(fmt.Sprintf(`(?i)^%s\b`, number)),
+		})
+	}
+	return patterns
+}
+
+// quoteAll escapes the words and joins them into a regular expression alternation.
+func quoteAll(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(word)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// roundHeaderPrefix matches anything that starts like a round header in one of the known languages.
+var roundHeaderPrefix = regexp.MustCompile(`(?i)^(?:round|kolo|runde)\s|^\d+\.\s*(?:kolo|runde)\b`)
+
+// roundHeader is the information extracted from a round header cell.
+type roundHeader struct {
+	Number   int
+	Date     string // Normalized to YYYY/MM/DD, empty when the header has no date
+	Time     string // HH:MM, empty when the header has no time
+	Language string
+}
+
+// looksLikeRoundHeader reports whether the cell text is meant to be a round header,
+// even when it cannot be parsed.
+func looksLikeRoundHeader(text string) bool {
+	return roundHeaderPrefix.MatchString(strings.TrimSpace(text))
+}
+
+// parseRoundHeader extracts the round number and the optional date and time from a round header
+// in any of the known languages. Returns false when the text matches none of the formats.
+func parseRoundHeader(text string) (roundHeader, bool) {
+	text = strings.TrimSpace(text)
+
+	// Formats with date and time first, so a simple pattern never hides them
+	for _, pattern := range roundPatterns {
+		if values := namedMatches(pattern.WithDate, text); values != nil {
+			number, _ := strconv.Atoi(values["round"] + values["roundSuffix"])
+			return roundHeader{
+				Number:   number,
+				Date:     normalizeDate(values["date"]),
+				Time:     normalizeTime(values["time"]),
+				Language: pattern.Language,
+			}, true
+		}
+	}
+
+	for _, pattern := range roundPatterns {
+		if values := namedMatches(pattern.Simple, text); values != nil {
+			number, _ := strconv.Atoi(values["round"] + values["roundSuffix"])
+			return roundHeader{Number: number, Language: pattern.Language}, true
+		}
+	}
+
+	return roundHeader{}, false
+}
+
+// namedMatches returns the named groups of the first match, or nil when the expression does not match.
+func namedMatches(re *regexp.Regexp, text string) map[string]string {
+	match := re.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+	values := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			values[name] = match[i]
+		}
+	}
+	return values
+}
+
+// dottedDate matches the day-first date format used by the Slovak, Czech and German exports.
+var dottedDate = regexp.MustCompile(`^(\d{1,2})\.\s?(\d{1,2})\.\s?(\d{4})$`)
+
+// slashedDate matches the year-first date format used by the English export.
+var slashedDate = regexp.MustCompile(`^(\d{4})/(\d{1,2})/(\d{1,2})$`)
+
+// normalizeDate converts the known date formats to YYYY/MM/DD.
+// Values in other formats are returned unchanged.
+func normalizeDate(date string) string {
+	date = strings.TrimSpace(date)
+	if match := dottedDate.FindStringSubmatch(date); match != nil {
+		return fmt.Sprintf("%s/%02s/%02s", match[3], match[2], match[1])
+	}
+	if match := slashedDate.FindStringSubmatch(date); match != nil {
+		return fmt.Sprintf("%s/%02s/%02s", match[1], match[2], match[3])
+	}
+	return date
+}
+
+// normalizeTime pads the hour of a H:MM time to two digits.
+func normalizeTime(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) == 4 && value[1] == ':' {
+		return "0" + value
+	}
+	return value
+}
@@ -0,0 +1,89 @@
+package excel
+
+import "testing"
+
+func TestParseRoundHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		want   roundHeader
+		wantOK bool
+	}{
+		{
+			name:   "english simple",
+			text:   "Round 1",
+			want:   roundHeader{Number: 1, Language: "en"},
+			wantOK: true,
+		},
+		{
+			name:   "english with date and time",
+			text:   "Round 3 on 2025/10/25 at 11:00",
+			want:   roundHeader{Number: 3, Date: "2025/10/25", Time: "11:00", Language: "en"},
+			wantOK: true,
+		},
+		{
+			name:   "english date without time falls back to the number",
+			text:   "Round 7 on 2025/10/25",
+			want:   roundHeader{Number: 7, Language: "en"},
+			wantOK: true,
+		},
+		{
+			name:   "slovak with date and time",
+			text:   "Kolo 2 dňa 25.10.2025 o 9:30",
+			want:   roundHeader{Number: 2, Date: "2025/10/25", Time: "09:30", Language: "sk"},
+			wantOK: true,
+		},
+		{
+			name:   "slovak suffix order with spaced date",
+			text:   "3. kolo dňa 8. 11. 2025 o 10:00",
+			want:   roundHeader{Number: 3, Date: "2025/11/08", Time: "10:00", Language: "sk"},
+			wantOK: true,
+		},
+		{
+			name:   "czech with date and time",
+			text:   "Kolo 4 dne 25.10.2025 v 11:00",
+			want:   roundHeader{Number: 4, Date: "2025/10/25", Time: "11:00", Language: "cs"},
+			wantOK: true,
+		},
+		{
+			name:   "german with date and time",
+			text:   "Runde 5 am 25.10.2025 um 14:00",
+			want:   roundHeader{Number: 5, Date: "2025/10/25", Time: "14:00", Language: "de"},
+			wantOK: true,
+		},
+		{
+			name:   "german suffix order",
+			text:   "2. Runde",
+			want:   roundHeader{Number: 2, Language: "de"},
+			wantOK: true,
+		},
+		{
+			name:   "case and surrounding spaces are ignored",
+			text:   "  ROUND 9  ",
+			want:   roundHeader{Number: 9, Language: "en"},
+			wantOK: true,
+		},
+		{
+			name:   "header without a number",
+			text:   "Runde x",
+			wantOK: false,
+		},
+		{
+			name:   "unrelated text",
+			text:   "Team standings",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRoundHeader(tt.text)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRoundHeader(%q) ok = %v, want %v", tt.text, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("parseRoundHeader(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
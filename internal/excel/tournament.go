@@ -0,0 +1,107 @@
+package excel
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TournamentInfo is the tournament metadata from the header block above the first round.
+type TournamentInfo struct {
+	Title        string `json:"title"`        // Tournament name (first row of the export)
+	Organizer    string `json:"organizer"`    // Organising body
+	Location     string `json:"location"`     // Venue or town, used as the default match address
+	StartDate    string `json:"startDate"`    // First day, YYYY/MM/DD
+	EndDate      string `json:"endDate"`      // Last day, YYYY/MM/DD
+	Director     string `json:"director"`     // Tournament director
+	ChiefArbiter string `json:"chiefArbiter"` // Chief arbiter
+	Federation   string `json:"federation"`   // Federation code, e.g. "SVK"
+}
+
+// tournamentLabels maps the localized header labels (lower case, without the trailing colon) to TournamentInfo fields.
+var tournamentLabels = map[string]string{
+	// English
+	"organizer": "organizer", "organizer(s)": "organizer", "organiser(s)": "organizer",
+	"location": "location", "venue": "location", "place": "location",
+	"date":                "date",
+	"tournament director": "director",
+	"chief arbiter":       "chiefArbiter",
+	"federation":          "federation",
+	// Slovak
+	"organizátor": "organizer", "organizátor(i)": "organizer", "usporiadateľ": "organizer",
+	"miesto": "location", "miesto konania": "location",
+	"dátum":            "date",
+	"riaditeľ turnaja": "director",
+	"hlavný rozhodca":  "chiefArbiter",
+	"federácia":        "federation",
+	// Czech
+	"organizátoři": "organizer", "pořadatel": "organizer",
+	"místo": "location", "místo konání": "location",
+	"datum":           "date",
+	"ředitel turnaje": "director",
+	"hlavní rozhodčí": "chiefArbiter",
+	"federace":        "federation",
+	// German
+	"veranstalter": "organizer", "organisator(en)": "organizer",
+	"ort": "location", "spielort": "location",
+	"turnierdirektor":     "director",
+	"hauptschiedsrichter": "chiefArbiter",
+	"föderation":          "federation",
+}
+
+// labelledValue splits "Label: value" cells and rows with the label and value in separate cells.
+var labelledValue = regexp.MustCompile(`^\s*([^:]+?)\s*:\s*(.*)$`)
+
+// exportDate finds the dates of a date range such as "2025/10/25 to 2026/03/15" or "25.10.2025 - 15.3.2026".
+var exportDate = regexp.MustCompile(datePattern)
+
+// parseTournamentRow reads one row of the header block into info.
+// The first unlabelled row is the tournament title; other unlabelled rows (e.g. "Last update") are ignored.
+func (info *TournamentInfo) parseTournamentRow(row []string) {
+	var cells []string
+	for _, value := range row {
+		if value = strings.TrimSpace(value); value != "" {
+			cells = append(cells, value)
+		}
+	}
+	if len(cells) == 0 {
+		return
+	}
+
+	label, value := "", ""
+	if match := labelledValue.FindStringSubmatch(cells[0]); match != nil {
+		label, value = match[1], match[2]
+		if value == "" && len(cells) > 1 {
+			value = strings.Join(cells[1:], " ")
+		}
+	} else if len(cells) > 1 {
+		label, value = cells[0], strings.Join(cells[1:], " ")
+	}
+
+	field, known := tournamentLabels[strings.ToLower(label)]
+	if !known {
+		if info.Title == "" && !strings.Contains(cells[0], ":") {
+			info.Title = cells[0]
+		}
+		return
+	}
+
+	value = strings.TrimSpace(value)
+	switch field {
+	case "organizer":
+		info.Organizer = value
+	case "location":
+		info.Location = value
+	case "director":
+		info.Director = value
+	case "chiefArbiter":
+		info.ChiefArbiter = value
+	case "federation":
+		info.Federation = value
+	case "date":
+		dates := exportDate.FindAllString(value, -1)
+		if len(dates) > 0 {
+			info.StartDate = normalizeDate(dates[0])
+			info.EndDate = normalizeDate(dates[len(dates)-1])
+		}
+	}
+}
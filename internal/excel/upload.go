@@ -0,0 +1,104 @@
+package excel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// MaxUploadSize limits uploaded schedule files; chess-results exports are a few dozen kilobytes.
+const MaxUploadSize = 10 << 20
+
+// uploadExtensions are the accepted schedule file extensions.
+// chess-results names its exports .xls although they are .xlsx workbooks; the content is checked by CheckWorkbookHeader.
+var uploadExtensions = []string{".xlsx", ".xls"}
+
+// ErrUnsupportedWorkbook is returned for uploads that are not .xlsx (Office Open XML) workbooks.
+var ErrUnsupportedWorkbook = errors.New("unsupported workbook")
+
+// ooxmlSignature starts every .xlsx workbook, which is a ZIP archive.
+var ooxmlSignature = []byte("PK\x03\x04")
+
+// biffSignature starts Excel 97-2003 workbooks (OLE compound files), which excelize cannot read.
+var biffSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// UploadTournamentID returns the tournament ID used for match IDs of an uploaded schedule:
+// the league's chess-results tournament when it has one, otherwise an ID derived from the league.
+func UploadTournamentID(league *data.League) string {
+	if tournamentID, err := ExtractTournamentIDFromLeague(league); err == nil {
+		return tournamentID
+	}
+	return "L" + league.LeagueId
+}
+
+// ValidateUploadFileName checks that an uploaded file looks like an Excel workbook.
+func ValidateUploadFileName(fileName string) error {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	for _, allowed := range uploadExtensions {
+		if extension == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported file type %q, expected .xlsx", extension)
+}
+
+// CheckWorkbookHeader reads the start of an uploaded file and accepts only .xlsx workbooks, whatever their name.
+// Excel 97-2003 workbooks and other files are reported as ErrUnsupportedWorkbook.
+func CheckWorkbookHeader(r io.Reader) error {
+	header := make([]byte, len(biffSignature))
+	n, _ := io.ReadFull(r, header)
+	header = header[:n]
+	switch {
+	case bytes.HasPrefix(header, ooxmlSignature):
+		return nil
+	case bytes.HasPrefix(header, biffSignature):
+		return fmt.Errorf("%w: Excel 97-2003 workbooks cannot be read, save the file as .xlsx and upload it again", ErrUnsupportedWorkbook)
+	default:
+		return fmt.Errorf("%w: the file is not an .xlsx workbook", ErrUnsupportedWorkbook)
+	}
+}
+
+// ParseUploadedExcel parses a schedule the user uploaded instead of downloading it from chess-results.
+// The file at filePath is removed afterwards. originalName is only used for the file type check and messages.
+// Match IDs are derived from tournamentID, so they agree with the IDs of a downloaded schedule.
+// The content must pass CheckWorkbookHeader, so chess-results exports named .xls parse normally
+// and genuine Excel 97-2003 workbooks are rejected before parsing.
+func ParseUploadedExcel(filePath, originalName, tournamentID string) (*ParseResult, error) {
+	defer func() {
+		if err := CleanupTempFile(filePath); err != nil {
+			logger.Error("Failed to cleanup uploaded Excel file %s: %v", filePath, err)
+		}
+	}()
+
+	if err := ValidateUploadFileName(originalName); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", originalName, err)
+	}
+	err = CheckWorkbookHeader(file)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ParseChessResultsExcel(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", originalName, err)
+	}
+
+	data.AssignMatchIDs(result.Rounds, tournamentID)
+
+	logger.Info("Parsed %d rounds from uploaded file %s (layout: %s, warnings: %d)",
+		len(result.Rounds), originalName, result.Dialect.Layout, len(result.Warnings))
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reasons reported in ParseWarning.Reason.
+const (
+	WarningMatchBeforeRound = "match_before_round" // A match row appeared before any round header
+	WarningTooFewColumns    = "too_few_columns"    // A row inside the pairing table has fewer than three columns
+	WarningUnknownRound     = "unknown_round"      // A round header matched none of the known formats
+	WarningMissingTeam      = "missing_team"       // A numbered pairing row has an empty team name
+	WarningMissingDateTime  = "missing_datetime"   // Neither the match row nor its round header has a date and time
+	WarningInvalidDateTime  = "invalid_datetime"   // The date or time of a match could not be parsed
+	WarningInvalidResult    = "invalid_result"     // The result cells of a match hold something that is not a score
+)
+
+// minMatchColumns is the number of columns a pairing row needs (number and both teams).
+const minMatchColumns = 3
+
+// ParseWarning describes a row the parser skipped or could not interpret with certainty.
+type ParseWarning struct {
+	Sheet   string   `json:"sheet"`   // Name of the worksheet
+	Row     int      `json:"row"`     // 1-based row number as shown in Excel
+	Content []string `json:"content"` // Raw cell values of the row
+	Reason  string   `json:"reason"`  // One of the Warning* reasons
+	Message string   `json:"message"` // Human readable explanation
+}
+
+// String formats the warning for log output.
+func (w ParseWarning) String() string {
+	return fmt.Sprintf("%s row %d: %s [%s]", w.Sheet, w.Row, w.Message, strings.Join(w.Content, " | "))
+}
+
+// newParseWarning creates a warning for the row at the 0-based index.
+func newParseWarning(sheet string, index int, row []string, reason, message string) ParseWarning {
+	return ParseWarning{
+		Sheet:   sheet,
+		Row:     index + 1,
+		Content: append([]string{}, row...),
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// isBlankRow reports whether all cells of the row are empty.
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
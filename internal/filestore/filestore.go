@@ -0,0 +1,41 @@
+// Package filestore holds the helpers shared by the JSON file stores (plans, fixtures, venues, aliases, leagues).
+package filestore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckLeagueID rejects league IDs that could point outside a store directory once used in a file name.
+func CheckLeagueID(leagueID string) error {
+	if leagueID == "" || strings.ContainsAny(leagueID, `/\`) || strings.Contains(leagueID, "..") {
+		return fmt.Errorf("invalid league ID: %q", leagueID)
+	}
+	return nil
+}
+
+// WriteJSONAtomic writes v as indented JSON to a temporary file next to path and renames it over path,
+// so a crash never leaves a truncated file. The parent directory is created when missing.
+func WriteJSONAtomic(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode: %v", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write: %v", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to replace %s: %v", path, err)
+	}
+	return nil
+}
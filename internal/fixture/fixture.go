@@ -0,0 +1,146 @@
+// Package fixture keeps placeholder fixtures of cup competitions, such as "Víťaz QF1" against "Víťaz QF2".
+// Arbiters must be booked before the pairings of a knockout round are known; a fixture holds the pairing number,
+// date and venue until the chess-results bracket lists the teams, which then replace the labels.
+package fixture
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/excel"
+)
+
+// Fixture is a match whose teams are not decided yet.
+// Round and Pairing identify the chess-results pairing it stands for, so its match ID stays the same once the teams are known.
+type Fixture struct {
+	Round      int    `json:"round"`      // Round number
+	Pairing    int    `json:"pairing"`    // Pairing number within the round, as chess-results will list it
+	HomeLabel  string `json:"homeLabel"`  // Stand-in for the home team, e.g. "Víťaz QF1"
+	GuestLabel string `json:"guestLabel"` // Stand-in for the guest team, e.g. "Víťaz QF2"
+	DateTime   string `json:"dateTime"`   // Date and time of the match (e.g., "2026/03/14 10:00")
+	Address    string `json:"address"`    // Venue address
+}
+
+// Normalize trims the text fields and checks that the fixture identifies a pairing.
+func (f *Fixture) Normalize() error {
+	f.HomeLabel = strings.TrimSpace(f.HomeLabel)
+	f.GuestLabel = strings.TrimSpace(f.GuestLabel)
+	f.DateTime = strings.TrimSpace(f.DateTime)
+	f.Address = strings.TrimSpace(f.Address)
+
+	if f.Round <= 0 {
+		return fmt.Errorf("round must be a positive number")
+	}
+	if f.Pairing <= 0 {
+		return fmt.Errorf("pairing must be a positive number")
+	}
+	if f.HomeLabel == "" {
+		f.HomeLabel = data.UndecidedTeam
+	}
+	if f.GuestLabel == "" {
+		f.GuestLabel = data.UndecidedTeam
+	}
+	if f.DateTime != "" {
+		if _, err := data.ParseDateTime(f.DateTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply returns a copy of rounds with the fixtures merged in, together with the number of fixtures
+// whose teams chess-results already lists. Such a match keeps its teams and only takes a missing date or venue
+// from the fixture. A missing pairing is added as a placeholder: from the export's undecided row of that pairing
+// when there is one (the missing teams get the fixture's labels), otherwise from the fixture alone.
+// Undecided rows without a fixture stay out of the schedule. The given rounds are left untouched.
+func Apply(rounds []data.Round, undecided []data.UndecidedPairing, fixtures []Fixture, tournamentID string) ([]data.Round, int) {
+	merged := make([]data.Round, len(rounds))
+	for i, round := range rounds {
+		merged[i] = round
+		merged[i].Matches = append([]data.MatchInfo{}, round.Matches...)
+	}
+
+	decided := 0
+	for _, fixture := range fixtures {
+		round := findRound(&merged, fixture)
+		match := findMatch(round, fixture.Pairing)
+		if match == nil {
+			placeholder := data.MatchInfo{Pairing: fixture.Pairing, DateTime: fixture.DateTime}
+			if row := findUndecided(undecided, fixture); row != nil {
+				placeholder = row.Match
+			}
+			placeholder.ID = data.MatchID(tournamentID, fixture.Round, fixture.Pairing)
+			placeholder.Placeholder = true
+			if placeholder.HomeTeam == "" {
+				placeholder.HomeTeam = fixture.HomeLabel
+			}
+			if placeholder.GuestTeam == "" {
+				placeholder.GuestTeam = fixture.GuestLabel
+			}
+			round.Matches = append(round.Matches, placeholder)
+			match = &round.Matches[len(round.Matches)-1]
+			match.StartsAt, match.DateTimeError = excel.ParseScheduleDateTime(match.DateTime)
+		} else {
+			decided++
+		}
+
+		if fixture.DateTime != "" && (match.DateTime == "" || match.DateTimeError != "") {
+			match.DateTime = fixture.DateTime
+			match.StartsAt, match.DateTimeError = excel.ParseScheduleDateTime(match.DateTime)
+		}
+		if match.Address == "" {
+			match.Address = fixture.Address
+		}
+		if round.DateTime == "" && match.DateTime != "" {
+			round.DateTime = match.DateTime
+			round.StartsAt, round.DateTimeError = excel.ParseScheduleDateTime(round.DateTime)
+		}
+	}
+
+	for i := range merged {
+		sort.SliceStable(merged[i].Matches, func(a, b int) bool {
+			return merged[i].Matches[a].Pairing < merged[i].Matches[b].Pairing
+		})
+		merged[i].UpdateStatus()
+	}
+	return merged, decided
+}
+
+// findRound returns the round of the fixture, adding it in round order when the schedule does not have it yet.
+func findRound(rounds *[]data.Round, fixture Fixture) *data.Round {
+	position := len(*rounds)
+	for i := range *rounds {
+		if (*rounds)[i].Number == fixture.Round {
+			return &(*rounds)[i]
+		}
+		if (*rounds)[i].Number > fixture.Round && position == len(*rounds) {
+			position = i
+		}
+	}
+
+	round := data.Round{Number: fixture.Round, Matches: []data.MatchInfo{}}
+	*rounds = append((*rounds)[:position], append([]data.Round{round}, (*rounds)[position:]...)...)
+	return &(*rounds)[position]
+}
+
+// findUndecided returns the export's undecided row of the fixture's round and pairing, or nil.
+func findUndecided(undecided []data.UndecidedPairing, fixture Fixture) *data.UndecidedPairing {
+	for i := range undecided {
+		if undecided[i].Round == fixture.Round && undecided[i].Match.Pairing == fixture.Pairing {
+			return &undecided[i]
+		}
+	}
+	return nil
+}
+
+// findMatch returns the match with the pairing number, or nil.
+func findMatch(round *data.Round, pairing int) *data.MatchInfo {
+	for i := range round.Matches {
+		if round.Matches[i].Pairing == pairing {
+			return &round.Matches[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package fixture
+
+import (
+	"reflect"
+	"testing"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// tournamentID is the chess-results tournament the test schedules belong to.
+const tournamentID = "123456"
+
+// mergedMatch is the part of a merged match the tests compare.
+type mergedMatch struct {
+	Round       int
+	ID          string
+	Pairing     int
+	HomeTeam    string
+	GuestTeam   string
+	DateTime    string
+	Address     string
+	Placeholder bool
+	Parsed      bool // StartsAt is set
+}
+
+// mergedMatches flattens merged rounds into comparable matches.
+func mergedMatches(rounds []data.Round) []mergedMatch {
+	matches := []mergedMatch{}
+	for _, round := range rounds {
+		for _, match := range round.Matches {
+			matches = append(matches, mergedMatch{
+				Round:       round.Number,
+				ID:          match.ID,
+				Pairing:     match.Pairing,
+				HomeTeam:    match.HomeTeam,
+				GuestTeam:   match.GuestTeam,
+				DateTime:    match.DateTime,
+				Address:     match.Address,
+				Placeholder: match.Placeholder,
+				Parsed:      match.StartsAt != nil,
+			})
+		}
+	}
+	return matches
+}
+
+// scheduledMatch builds a parsed schedule match.
+func scheduledMatch(round, pairing int, home, guest, dateTime, address string) data.MatchInfo {
+	match := data.MatchInfo{
+		ID:        data.MatchID(tournamentID, round, pairing),
+		Pairing:   pairing,
+		HomeTeam:  home,
+		GuestTeam: guest,
+		DateTime:  dateTime,
+		Address:   address,
+	}
+	if startsAt, err := data.ParseDateTime(dateTime); err == nil {
+		match.StartsAt = &startsAt
+	} else {
+		match.DateTimeError = err.Error()
+	}
+	return match
+}
+
+func TestApply(t *testing.T) {
+	schedule := []data.Round{
+		{Number: 1, DateTime: "2026/03/14 10:00", Matches: []data.MatchInfo{
+			scheduledMatch(1, 1, "ŠK Prievidza", "ŠKŠ Dubnica", "2026/03/14 10:00", "Hall A"),
+		}},
+		{Number: 4, DateTime: "2026/05/09 10:00", Matches: []data.MatchInfo{
+			scheduledMatch(4, 1, "ŠK Modra", "ŠK Prievidza", "2026/05/09 10:00", ""),
+		}},
+	}
+	unchanged := mergedMatches(schedule)
+
+	tests := []struct {
+		name        string
+		rounds      []data.Round
+		undecided   []data.UndecidedPairing
+		fixtures    []Fixture
+		want        []mergedMatch
+		wantRounds  []int
+		wantDecided int
+	}{
+		{
+			name:       "no fixtures",
+			rounds:     schedule,
+			want:       unchanged,
+			wantRounds: []int{1, 4},
+		},
+		{
+			name:   "missing pairing becomes a placeholder",
+			rounds: schedule,
+			fixtures: []Fixture{
+				{Round: 1, Pairing: 2, HomeLabel: "Víťaz QF1", GuestLabel: "Víťaz QF2", DateTime: "2026/03/14 14:00", Address: "Gym"},
+			},
+			want: []mergedMatch{
+				unchanged[0],
+				{Round: 1, ID: data.MatchID(tournamentID, 1, 2), Pairing: 2, HomeTeam: "Víťaz QF1", GuestTeam: "Víťaz QF2",
+					DateTime: "2026/03/14 14:00", Address: "Gym", Placeholder: true, Parsed: true},
+				unchanged[1],
+			},
+			wantRounds: []int{1, 4},
+		},
+		{
+			name:   "missing round is added in order",
+			rounds: schedule,
+			fixtures: []Fixture{
+				{Round: 3, Pairing: 1, HomeLabel: "Víťaz QF1", GuestLabel: "Víťaz QF2", DateTime: "2026/04/18 10:00"},
+			},
+			want: []mergedMatch{
+				unchanged[0],
+				{Round: 3, ID: data.MatchID(tournamentID, 3, 1), Pairing: 1, HomeTeam: "Víťaz QF1", GuestTeam: "Víťaz QF2",
+					DateTime: "2026/04/18 10:00", Placeholder: true, Parsed: true},
+				unchanged[1],
+			},
+			wantRounds: []int{1, 3, 4},
+		},
+		{
+			name:   "decided pairing keeps its teams and takes a missing venue",
+			rounds: schedule,
+			fixtures: []Fixture{
+				{Round: 4, Pairing: 1, HomeLabel: "Víťaz SF1", GuestLabel: "Víťaz SF2", DateTime: "2026/05/10 10:00", Address: "Gym"},
+			},
+			want: []mergedMatch{
+				unchanged[0],
+				{Round: 4, ID: data.MatchID(tournamentID, 4, 1), Pairing: 1, HomeTeam: "ŠK Modra", GuestTeam: "ŠK Prievidza",
+					DateTime: "2026/05/09 10:00", Address: "Gym", Parsed: true},
+			},
+			wantRounds:  []int{1, 4},
+			wantDecided: 1,
+		},
+		{
+			name: "decided pairing with an invalid date takes the fixture's date",
+			rounds: []data.Round{
+				{Number: 1, Matches: []data.MatchInfo{scheduledMatch(1, 1, "ŠK Prievidza", "ŠKŠ Dubnica", "2026/13/14 10:00", "Hall A")}},
+			},
+			fixtures: []Fixture{
+				{Round: 1, Pairing: 1, DateTime: "2026/03/14 10:00"},
+			},
+			want: []mergedMatch{
+				{Round: 1, ID: data.MatchID(tournamentID, 1, 1), Pairing: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica",
+					DateTime: "2026/03/14 10:00", Address: "Hall A", Parsed: true},
+			},
+			wantRounds:  []int{1},
+			wantDecided: 1,
+		},
+		{
+			name:   "undecided row keeps its known team",
+			rounds: schedule,
+			undecided: []data.UndecidedPairing{
+				{Round: 1, Match: data.MatchInfo{Pairing: 2, HomeTeam: "ŠK Modra", DateTime: "2026/03/14 10:00", Address: "Hall B"}},
+			},
+			fixtures: []Fixture{
+				{Round: 1, Pairing: 2, HomeLabel: "Víťaz QF1", GuestLabel: "Víťaz QF2"},
+			},
+			want: []mergedMatch{
+				unchanged[0],
+				{Round: 1, ID: data.MatchID(tournamentID, 1, 2), Pairing: 2, HomeTeam: "ŠK Modra", GuestTeam: "Víťaz QF2",
+					DateTime: "2026/03/14 10:00", Address: "Hall B", Placeholder: true, Parsed: true},
+				unchanged[1],
+			},
+			wantRounds: []int{1, 4},
+		},
+		{
+			name:   "undecided row without a fixture stays out",
+			rounds: schedule,
+			undecided: []data.UndecidedPairing{
+				{Round: 1, Match: data.MatchInfo{Pairing: 2, HomeTeam: "ŠK Modra", DateTime: "2026/03/14 10:00"}},
+			},
+			want:       unchanged,
+			wantRounds: []int{1, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := mergedMatches(tt.rounds)
+			merged, decided := Apply(tt.rounds, tt.undecided, tt.fixtures, tournamentID)
+
+			if got := mergedMatches(merged); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matches = %+v, want %+v", got, tt.want)
+			}
+			var numbers []int
+			for _, round := range merged {
+				numbers = append(numbers, round.Number)
+				if round.DateTime == "" {
+					t.Errorf("round %d has no date and time", round.Number)
+				}
+			}
+			if !reflect.DeepEqual(numbers, tt.wantRounds) {
+				t.Errorf("rounds = %v, want %v", numbers, tt.wantRounds)
+			}
+			if decided != tt.wantDecided {
+				t.Errorf("decided = %d, want %d", decided, tt.wantDecided)
+			}
+			if after := mergedMatches(tt.rounds); !reflect.DeepEqual(after, before) {
+				t.Errorf("Apply modified the given rounds: %+v", after)
+			}
+		})
+	}
+}
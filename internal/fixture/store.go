@@ -0,0 +1,134 @@
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/filestore"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// DefaultDir is where fixtures are stored, one JSON file per league.
+const DefaultDir = "assets/fixtures/"
+
+// ErrNotFound is returned when removing a fixture the league does not have.
+var ErrNotFound = fmt.Errorf("fixture not found")
+
+// Store persists the fixtures of each league as JSON files.
+type Store struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewStore creates a store keeping its files in dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// path returns the file of a league's fixtures.
+func (s *Store) path(leagueID string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("league_%s.json", leagueID))
+}
+
+// Load returns the fixtures of a league ordered by round and pairing.
+func (s *Store) Load(leagueID string) ([]Fixture, error) {
+	if err := filestore.CheckLeagueID(leagueID); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(leagueID)
+}
+
+// Save validates the fixture and stores it, replacing a fixture with the same round and pairing.
+// Returns the stored fixture.
+func (s *Store) Save(leagueID string, fixture Fixture) (*Fixture, error) {
+	if err := filestore.CheckLeagueID(leagueID); err != nil {
+		return nil, err
+	}
+	if err := fixture.Normalize(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fixtures, err := s.load(leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := false
+	for i, existing := range fixtures {
+		if existing.Round == fixture.Round && existing.Pairing == fixture.Pairing {
+			fixtures[i] = fixture
+			replaced = true
+		}
+	}
+	if !replaced {
+		fixtures = append(fixtures, fixture)
+	}
+
+	if err := s.save(leagueID, fixtures); err != nil {
+		return nil, err
+	}
+	return &fixture, nil
+}
+
+// Remove deletes the league's fixture of the given round and pairing.
+func (s *Store) Remove(leagueID string, round, pairing int) error {
+	if err := filestore.CheckLeagueID(leagueID); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fixtures, err := s.load(leagueID)
+	if err != nil {
+		return err
+	}
+	for i, fixture := range fixtures {
+		if fixture.Round == round && fixture.Pairing == pairing {
+			return s.save(leagueID, append(fixtures[:i], fixtures[i+1:]...))
+		}
+	}
+	return ErrNotFound
+}
+
+// load reads the fixtures without locking.
+func (s *Store) load(leagueID string) ([]Fixture, error) {
+	content, err := os.ReadFile(s.path(leagueID))
+	if os.IsNotExist(err) {
+		return []Fixture{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures for league %s: %v", leagueID, err)
+	}
+
+	var fixtures []Fixture
+	if err := json.Unmarshal(content, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures for league %s: %v", leagueID, err)
+	}
+	return fixtures, nil
+}
+
+// save sorts the fixtures and writes them atomically, so a crash never leaves a truncated file.
+func (s *Store) save(leagueID string, fixtures []Fixture) error {
+	sort.SliceStable(fixtures, func(i, j int) bool {
+		if fixtures[i].Round != fixtures[j].Round {
+			return fixtures[i].Round < fixtures[j].Round
+		}
+		return fixtures[i].Pairing < fixtures[j].Pairing
+	})
+
+	if err := filestore.WriteJSONAtomic(s.path(leagueID), fixtures); err != nil {
+		return fmt.Errorf("failed to store fixtures: %v", err)
+	}
+
+	logger.Debug("Saved %d fixtures for league %s", len(fixtures), leagueID)
+	return nil
+}
@@ -0,0 +1,186 @@
+// Package leagues stores leagues that are not published by the chess.sk API,
+// such as regional leagues and one-off team events, so they can be delegated like any other league.
+package leagues
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/excel"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/filestore"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// DefaultPath is where custom leagues are stored.
+const DefaultPath = "assets/custom_leagues.json"
+
+// ErrNotFound is returned when removing a league that is not a custom league.
+var ErrNotFound = fmt.Errorf("custom league not found")
+
+// tournamentIDPattern matches a bare chess-results tournament ID.
+var tournamentIDPattern = regexp.MustCompile(`^\d+$`)
+
+// Request describes a league to register.
+type Request struct {
+	LeagueName        string `json:"leagueName"`        // Display name of the league
+	SaisonName        string `json:"saisonName"`        // Season name (e.g., "2025/2026")
+	DirectorFirstName string `json:"directorFirstName"` // Director's first name
+	DirectorSurname   string `json:"directorSurname"`   // Director's surname
+	DirectorEmail     string `json:"directorEmail"`     // Director's email address
+	ChessResults      string `json:"chessResults"`      // chess-results URL or tournament ID
+}
+
+// customFile is the content of the custom leagues file.
+type customFile struct {
+	NextID  int           `json:"nextId"`  // ID given to the next added league, only ever decreases
+	Leagues []data.League `json:"leagues"` // Leagues in the order they were added
+}
+
+// Store persists custom leagues as a JSON file.
+// Custom leagues get negative IDs (-1, -2, ...) so they never collide with chess.sk league IDs.
+// IDs are never reused, so plans and fixtures of a removed league are not picked up by a new one.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a store keeping the leagues in the file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Load returns all custom leagues.
+func (s *Store) Load() ([]data.League, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return file.Leagues, nil
+}
+
+// Add validates the request and stores it as a new league. Returns the stored league.
+func (s *Store) Add(request Request) (*data.League, error) {
+	league, err := newLeague(request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	league.LeagueId = strconv.Itoa(file.NextID)
+	file.NextID--
+	file.Leagues = append(file.Leagues, *league)
+	if err := s.save(file); err != nil {
+		return nil, err
+	}
+	return league, nil
+}
+
+// Remove deletes the custom league with the given ID.
+func (s *Store) Remove(leagueID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.load()
+	if err != nil {
+		return err
+	}
+	for i, league := range file.Leagues {
+		if league.LeagueId == leagueID {
+			file.Leagues = append(file.Leagues[:i], file.Leagues[i+1:]...)
+			return s.save(file)
+		}
+	}
+	return ErrNotFound
+}
+
+// newLeague builds a league from the request. A bare tournament ID is turned into a chess-results link.
+func newLeague(request Request) (*data.League, error) {
+	league := &data.League{
+		LeagueName:        strings.TrimSpace(request.LeagueName),
+		SaisonName:        strings.TrimSpace(request.SaisonName),
+		DirectorFirstName: strings.TrimSpace(request.DirectorFirstName),
+		DirectorSurname:   strings.TrimSpace(request.DirectorSurname),
+		DirectorEmail:     strings.TrimSpace(request.DirectorEmail),
+		Custom:            true,
+	}
+	if league.LeagueName == "" {
+		return nil, fmt.Errorf("league name is required")
+	}
+	if league.DirectorSurname == "" {
+		return nil, fmt.Errorf("director is required")
+	}
+
+	link := strings.TrimSpace(request.ChessResults)
+	if tournamentIDPattern.MatchString(link) {
+		link = fmt.Sprintf("https://chess-results.com/tnr%s.aspx", link)
+	}
+	league.ChessResultsLink = link
+	if _, err := excel.ExtractTournamentIDFromLeague(league); err != nil {
+		return nil, fmt.Errorf("invalid chess-results link or tournament ID: %q", request.ChessResults)
+	}
+	return league, nil
+}
+
+// load reads the leagues without locking.
+// Files written before the ID counter was stored hold a plain list of leagues; their counter continues
+// below the lowest ID in the list.
+func (s *Store) load() (*customFile, error) {
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &customFile{NextID: -1, Leagues: []data.League{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom leagues: %v", err)
+	}
+
+	file := &customFile{}
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("[")) {
+		err = json.Unmarshal(content, &file.Leagues)
+	} else {
+		err = json.Unmarshal(content, file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse custom leagues: %v", err)
+	}
+	if file.Leagues == nil {
+		file.Leagues = []data.League{}
+	}
+
+	// Never hand out an ID that is still in use, even if the counter was lost or edited
+	for _, league := range file.Leagues {
+		if id, err := strconv.Atoi(league.LeagueId); err == nil && id <= file.NextID {
+			file.NextID = id - 1
+		}
+	}
+	if file.NextID >= 0 {
+		file.NextID = -1
+	}
+	return file, nil
+}
+
+// save writes the leagues atomically, so a crash never leaves a truncated file.
+func (s *Store) save(file *customFile) error {
+	if err := filestore.WriteJSONAtomic(s.path, file); err != nil {
+		return fmt.Errorf("failed to store custom leagues: %v", err)
+	}
+
+	logger.Debug("Saved %d custom leagues", len(file.Leagues))
+	return nil
+}
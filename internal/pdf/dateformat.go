@@ -0,0 +1,37 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// DefaultDateFormat renders match times like "sobota 25. 10. 2025 o 11:00".
+const DefaultDateFormat = "{weekday} {day}. {month}. {year} o {hour}:{minute}"
+
+// slovakWeekdays are the weekday names used by the {weekday} placeholder, indexed by time.Weekday.
+var slovakWeekdays = [...]string{"nedeľa", "pondelok", "utorok", "streda", "štvrtok", "piatok", "sobota"}
+
+// FormatMatchDateTime renders t in the Europe/Bratislava zone using a date format pattern.
+// Supported placeholders are {weekday}, {day}, {day2}, {month}, {month2}, {year},
+// {hour}, {hour2} and {minute}; the "2" variants are zero-padded to two digits.
+func FormatMatchDateTime(t time.Time, format string) string {
+	if format == "" {
+		format = DefaultDateFormat
+	}
+	t = t.In(data.TimeZone)
+
+	return expandPattern(format, map[string]string{
+		"weekday": slovakWeekdays[t.Weekday()],
+		"day":     strconv.Itoa(t.Day()),
+		"day2":    fmt.Sprintf("%02d", t.Day()),
+		"month":   strconv.Itoa(int(t.Month())),
+		"month2":  fmt.Sprintf("%02d", int(t.Month())),
+		"year":    strconv.Itoa(t.Year()),
+		"hour":    strconv.Itoa(t.Hour()),
+		"hour2":   fmt.Sprintf("%02d", t.Hour()),
+		"minute":  fmt.Sprintf("%02d", t.Minute()),
+	})
+}
@@ -0,0 +1,104 @@
+package pdf
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// OwnerPasswordEnv is the environment variable that overrides the configured owner password,
+// so the password does not have to be stored next to the template.
+const OwnerPasswordEnv = "PDF_OWNER_PASSWORD"
+
+// EncryptionSettings configures password protection of generated documents.
+type EncryptionSettings struct {
+	Enabled          bool     `json:"enabled"`          // Whether generated documents are encrypted
+	OwnerPassword    string   `json:"ownerPassword"`    // Password required to change permissions (overridden by PDF_OWNER_PASSWORD)
+	UserPasswordRule string   `json:"userPasswordRule"` // Pattern for the password needed to open a document, empty for none
+	Permissions      []string `json:"permissions"`      // Allowed operations, see permissionFlags
+}
+
+// permissionFlags maps permission names used in the settings to pdfcpu permission bits.
+var permissionFlags = map[string]model.PermissionFlags{
+	"print":    model.PermissionPrintRev2 + model.PermissionPrintRev3,
+	"modify":   model.PermissionModify,
+	"extract":  model.PermissionExtract + model.PermissionExtractRev3,
+	"annotate": model.PermissionModAnnFillForm,
+	"fill":     model.PermissionFillRev3,
+	"assemble": model.PermissionAssembleRev3,
+}
+
+// defaultPermissions are used when encryption is enabled without an explicit permission list.
+var defaultPermissions = []string{"print"}
+
+// resolvePermissions converts permission names to the PDF permission flags.
+func resolvePermissions(names []string) (model.PermissionFlags, error) {
+	if len(names) == 0 {
+		names = defaultPermissions
+	}
+
+	flags := model.PermissionsNone
+	for _, name := range names {
+		flag, ok := permissionFlags[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("unknown PDF permission: %s", name)
+		}
+		flags |= flag
+	}
+	return flags, nil
+}
+
+// UserPassword derives the password needed to open a document from the rule.
+// The rule uses the same placeholders as file names, e.g. "{playerid}" or "{lastname}{playerid}".
+// The result is transliterated to ASCII and stripped of whitespace so it can be typed in any PDF reader.
+func UserPassword(rule string, pdfData data.PDFData) string {
+	return userPassword(rule, FileNameValues(pdfData, pdfData.DocumentNumber))
+}
+
+// userPassword resolves the rule with the given placeholder values, see UserPassword.
+func userPassword(rule string, values map[string]string) string {
+	if rule == "" {
+		return ""
+	}
+	password := Transliterate(expandPattern(rule, values))
+	return strings.Join(strings.Fields(password), "")
+}
+
+// applyEncryption configures the PDF context so it is written AES-256 encrypted.
+// values are the document's placeholder values used by the user password rule.
+// Does nothing when encryption is disabled.
+func applyEncryption(ctx *model.Context, settings EncryptionSettings, values map[string]string) error {
+	if !settings.Enabled {
+		return nil
+	}
+
+	ownerPassword := settings.OwnerPassword
+	if env := os.Getenv(OwnerPasswordEnv); env != "" {
+		ownerPassword = env
+	}
+	if ownerPassword == "" {
+		return fmt.Errorf("encryption is enabled but no owner password is configured (set %s)", OwnerPasswordEnv)
+	}
+
+	permissions, err := resolvePermissions(settings.Permissions)
+	if err != nil {
+		return err
+	}
+
+	userPW := userPassword(settings.UserPasswordRule, values)
+	if settings.UserPasswordRule != "" && userPW == "" {
+		return fmt.Errorf("user password rule %q produced an empty password", settings.UserPasswordRule)
+	}
+
+	ctx.Cmd = model.ENCRYPT
+	ctx.OwnerPW = ownerPassword
+	ctx.UserPW = userPW
+	ctx.EncryptUsingAES = true
+	ctx.EncryptKeyLength = 256
+	ctx.Permissions = permissions
+
+	return nil
+}
@@ -5,23 +5,37 @@ package pdf
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
 	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
-	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 // FillForm fills a PDF form with the provided data and saves it to a new file.
 // It reads the PDF template, fills in the form fields with the provided data map,
-// and saves the result to a new file with a unique name.
+// and saves the result to the results directory under a unique name derived from fileName.
 // Returns the path to the filled PDF file or an error if the operation fails.
-func FillForm(pdfPath string, data map[string]string) (string, error) {
+func FillForm(pdfPath string, data map[string]string, fileName string) (string, error) {
+	ctx, err := fillFormContext(pdfPath, data)
+	if err != nil {
+		return "", err
+	}
+
+	return writeResultFile(ctx, fileName)
+}
+
+// fillFormContext reads the PDF template and fills in the form fields with the provided data map.
+// Returns the in-memory PDF context so further processing can happen before it is written.
+func fillFormContext(pdfPath string, data map[string]string) (*model.Context, error) {
 	// Read the PDF file into a context
 	ctx, err := api.ReadContextFile(pdfPath)
 	if err != nil {
-		return "", fmt.Errorf("error reading PDF file: %v", err)
+		return nil, fmt.Errorf("error reading PDF file: %v", err)
 	}
 
 	// Create a field processor function
@@ -35,23 +49,32 @@ func FillForm(pdfPath string, data map[string]string) (string, error) {
 	// Fill the form fields using the correct API
 	_, _, err = form.FillForm(ctx, fieldProcessor, nil, form.DataFormat(0))
 	if err != nil {
-		return "", fmt.Errorf("error filling form fields: %v", err)
+		return nil, fmt.Errorf("error filling form fields: %v", err)
 	}
 
-	// Generate unique output filename with UUID
-	outputPath := fmt.Sprintf("assets/results/%s_%s_%s.pdf", data["text_1nzhs"], data["text_2qqiu"], uuid.New().String()[:8])
-
-	logger.Debug("Generated PDF filename: %s", outputPath)
+	return ctx, nil
+}
 
+// writeResultFile writes a PDF context to the results directory under a unique name derived from fileName.
+// Returns the path to the written file.
+func writeResultFile(ctx *model.Context, fileName string) (string, error) {
 	// Ensure the results directory exists
 	resultsDir := "assets/results"
 	if err := os.MkdirAll(resultsDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create results directory: %v", err)
 	}
 
-	// Write the filled PDF
-	err = api.WriteContextFile(ctx, outputPath)
+	// Reserve a unique output filename
+	outputPath, err := reserveUniquePath(resultsDir, fileName)
 	if err != nil {
+		return "", err
+	}
+
+	logger.Debug("Generated PDF filename: %s", outputPath)
+
+	// Write the filled PDF
+	if err := api.WriteContextFile(ctx, outputPath); err != nil {
+		os.Remove(outputPath)
 		return "", fmt.Errorf("error writing filled PDF: %v", err)
 	}
 
@@ -59,42 +82,110 @@ func FillForm(pdfPath string, data map[string]string) (string, error) {
 }
 
 // generateSinglePDF generates a single PDF from PDFData
-func generateSinglePDF(pdfData data.PDFData, templatePath string, index int) (string, error) {
+func generateSinglePDF(pdfData data.PDFData, template TemplateConfig, index int) (GeneratedDocument, error) {
 	// Validate the PDF data
 	if err := validatePDFData(pdfData); err != nil {
-		return "", fmt.Errorf("validation failed for item %d: %v", index, err)
+		return GeneratedDocument{}, fmt.Errorf("validation failed for item %d: %v", index, err)
+	}
+
+	// Documents without an explicit number are numbered by their position in the batch
+	if pdfData.DocumentNumber == "" {
+		pdfData.DocumentNumber = fmt.Sprintf("%03d", index+1)
+	}
+
+	// Fill the form for this data
+	ctx, err := fillMatchTemplate(pdfData, template)
+	if err != nil {
+		return GeneratedDocument{}, fmt.Errorf("error generating PDF for item %d: %v", index, err)
+	}
+
+	// Set document properties (info dictionary and XMP)
+	if err := applyMetadata(ctx, BuildDocumentMetadata(pdfData, template.Metadata)); err != nil {
+		return GeneratedDocument{}, fmt.Errorf("error setting metadata for item %d: %v", index, err)
 	}
 
+	// Password protect the document if the template asks for it
+	values := FileNameValues(pdfData, pdfData.DocumentNumber)
+	if err := applyEncryption(ctx, template.Encryption, values); err != nil {
+		return GeneratedDocument{}, fmt.Errorf("error setting encryption for item %d: %v", index, err)
+	}
+
+	// Build the output file name from the template's naming scheme and write the PDF
+	fileName := BuildFileName(template.FileNamePattern, values)
+	outputPath, err := writeResultFile(ctx, fileName)
+	if err != nil {
+		return GeneratedDocument{}, fmt.Errorf("error generating PDF for item %d: %v", index, err)
+	}
+
+	return GeneratedDocument{Path: outputPath, Data: pdfData}, nil
+}
+
+// noteStampDescription configures the note printed below the form (pdfcpu stamp syntax).
+const noteStampDescription = "font:Helvetica, points:9, scale:1 abs, rot:0, pos:bl, off:40 30, fillcolor:#000000"
+
+// fillMatchTemplate maps a match delegation to the template's fields and fills the form.
+// Arbiter roles and the matches of a venue group go to the template's optional fields,
+// or are printed as a note when it has none.
+func fillMatchTemplate(pdfData data.PDFData, template TemplateConfig) (*model.Context, error) {
 	// Map data to fields using the same logic as the original
-	fieldData := MapDataToFields(pdfData, DefaultFieldMapping)
+	fieldData := MapDataToFields(pdfData, template.Mapping, template.DateFormat)
+	mapRoleFields(fieldData, pdfData, template.RoleFields)
+	mapMatchList(fieldData, pdfData, template)
 
-	// Generate PDF for this data - same as original line 161
-	outputPath, err := FillForm(templatePath, fieldData)
+	ctx, err := fillFormContext(template.Path, fieldData)
 	if err != nil {
-		return "", fmt.Errorf("error generating PDF for item %d: %v", index, err)
+		return nil, err
 	}
 
-	return outputPath, nil
+	var notes []string
+	for _, note := range []string{roleNote(pdfData, template.RoleFields), matchesNote(pdfData, template)} {
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	if err := addNote(ctx, strings.Join(notes, "\n")); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// addNote stamps a note, one line per "\n", at the bottom of the first page.
+func addNote(ctx *model.Context, note string) error {
+	if note == "" {
+		return nil
+	}
+
+	wm, err := pdfcpu.ParseTextWatermarkDetails(coreFontFallbacks.Replace(note), noteStampDescription, true, types.POINTS)
+	if err != nil {
+		return fmt.Errorf("error preparing note: %v", err)
+	}
+	if err := pdfcpu.AddWatermarks(ctx, types.IntSet{1: true}, wm); err != nil {
+		return fmt.Errorf("error adding note: %v", err)
+	}
+	return nil
 }
 
-// GeneratePDFsFromDelegateArbiters generates PDF files for each delegate-arbiter data
-func GeneratePDFsFromDelegateArbiters(pdfDataArray []data.PDFData, templatePath string) ([]string, error) {
-	if err := validateTemplate(templatePath); err != nil {
+// GeneratePDFsFromDelegateArbiters generates PDF files for each delegate-arbiter data.
+// Matches with several arbiters are expanded first, see ExpandOfficials.
+func GeneratePDFsFromDelegateArbiters(pdfDataArray []data.PDFData, template TemplateConfig) ([]GeneratedDocument, error) {
+	if err := validateTemplate(template.Path); err != nil {
 		return nil, err
 	}
+	pdfDataArray = ExpandOfficials(pdfDataArray, template.RoleFields)
 
 	// Process each PDF data item
-	var generatedFiles []string
+	var documents []GeneratedDocument
 	for i, pdfData := range pdfDataArray {
-		filePath, err := generateSinglePDF(pdfData, templatePath, i)
+		document, err := generateSinglePDF(pdfData, template, i)
 		if err != nil {
+			removeDocuments(documents)
 			return nil, err
 		}
 
-		generatedFiles = append(generatedFiles, filePath)
-		logger.Debug("Generated PDF %d/%d: %s", i+1, len(pdfDataArray), filePath)
+		documents = append(documents, document)
+		logger.Debug("Generated PDF %d/%d: %s", i+1, len(pdfDataArray), document.Path)
 	}
 
-	logger.Info("Generated %d PDF files", len(generatedFiles))
-	return generatedFiles, nil
+	logger.Info("Generated %d PDF files", len(documents))
+	return documents, nil
 }
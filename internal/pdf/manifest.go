@@ -0,0 +1,125 @@
+package pdf
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// ManifestEntry describes one document of a delegation package.
+// The same entries are written to manifest.csv and manifest.json.
+type ManifestEntry struct {
+	DocumentNumber string `json:"documentNumber"` // Delegation number
+	MatchID        string `json:"matchId"`        // Stable match identifier, empty for matches entered by hand
+	Round          int    `json:"round"`          // Round number (0 when unknown)
+	Match          string `json:"match"`          // "<Home> - <Guest>", all matches separated by "; " for a venue group
+	HomeTeam       string `json:"homeTeam"`       // Name of the home team
+	GuestTeam      string `json:"guestTeam"`      // Name of the guest team
+	DateTime       string `json:"dateTime"`       // Date and time of the match
+	Venue          string `json:"venue"`          // Venue address
+	Arbiter        string `json:"arbiter"`        // Arbiter's full name
+	ArbiterID      string `json:"arbiterId"`      // Arbiter's player ID
+	Role           string `json:"role"`           // Arbiter's role, empty for matches with a single arbiter
+	CoOfficials    string `json:"coOfficials"`    // Other arbiters of the match with their roles
+	Director       string `json:"director"`       // League director contact
+	FileName       string `json:"fileName"`       // Path of the document inside the package
+}
+
+// manifestCSVHeader lists the CSV columns in the order they are written.
+var manifestCSVHeader = []string{
+	"documentNumber", "matchId", "round", "match", "homeTeam", "guestTeam", "dateTime",
+	"venue", "arbiter", "arbiterId", "role", "coOfficials", "director", "fileName",
+}
+
+// newManifestEntry builds the manifest entry for a document stored in the package as nameInZip.
+func newManifestEntry(document GeneratedDocument, nameInZip string) ManifestEntry {
+	pdfData := document.Data
+	return ManifestEntry{
+		DocumentNumber: pdfData.DocumentNumber,
+		MatchID:        pdfData.Match.MatchID,
+		Round:          pdfData.Match.Round,
+		Match:          describeMatches(pdfData.CoveredMatches()),
+		HomeTeam:       pdfData.Match.HomeTeam,
+		GuestTeam:      pdfData.Match.GuestTeam,
+		DateTime:       pdfData.Match.DateTime,
+		Venue:          pdfData.Match.Address,
+		Arbiter:        strings.TrimSpace(pdfData.Arbiter.FirstName + " " + pdfData.Arbiter.LastName),
+		ArbiterID:      pdfData.Arbiter.PlayerID,
+		Role:           pdfData.Role,
+		CoOfficials:    describeOfficials(pdfData.CoOfficials()),
+		Director:       pdfData.Director.Contact,
+		FileName:       nameInZip,
+	}
+}
+
+// describeMatches lists matches as "<Home> - <Guest>" separated by "; ".
+func describeMatches(matches []data.MatchData) string {
+	var parts []string
+	for _, match := range matches {
+		parts = append(parts, fmt.Sprintf("%s - %s", match.HomeTeam, match.GuestTeam))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// writeManifest adds manifest.csv and manifest.json to the archive.
+func writeManifest(zipWriter *zip.Writer, entries []ManifestEntry) error {
+	if entries == nil {
+		entries = []ManifestEntry{}
+	}
+
+	// CSV - starts with a UTF-8 BOM so spreadsheet applications detect the encoding (diacritics)
+	csvWriter, err := createZipEntry(zipWriter, "manifest.csv")
+	if err != nil {
+		return fmt.Errorf("failed to create manifest.csv: %v", err)
+	}
+	if _, err := csvWriter.Write([]byte("\ufeff")); err != nil {
+		return fmt.Errorf("failed to write manifest.csv: %v", err)
+	}
+
+	w := csv.NewWriter(csvWriter)
+	if err := w.Write(manifestCSVHeader); err != nil {
+		return fmt.Errorf("failed to write manifest.csv: %v", err)
+	}
+	for _, entry := range entries {
+		record := []string{
+			entry.DocumentNumber, entry.MatchID, strconv.Itoa(entry.Round), entry.Match, entry.HomeTeam, entry.GuestTeam,
+			entry.DateTime, entry.Venue, entry.Arbiter, entry.ArbiterID, entry.Role, entry.CoOfficials, entry.Director, entry.FileName,
+		}
+		if err := w.Write(record); err != nil {
+			return fmt.Errorf("failed to write manifest.csv: %v", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write manifest.csv: %v", err)
+	}
+
+	// JSON
+	jsonWriter, err := createZipEntry(zipWriter, "manifest.json")
+	if err != nil {
+		return fmt.Errorf("failed to create manifest.json: %v", err)
+	}
+	encoder := json.NewEncoder(jsonWriter)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(entries); err != nil {
+		return fmt.Errorf("failed to write manifest.json: %v", err)
+	}
+
+	return nil
+}
+
+// createZipEntry adds a compressed entry generated in memory, stamped with the current time.
+func createZipEntry(zipWriter *zip.Writer, name string) (io.Writer, error) {
+	return zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+}
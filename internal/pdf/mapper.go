@@ -34,7 +34,8 @@ var DefaultFieldMapping = FieldMapping{
 
 // MapDataToFields converts PDFData to the field mapping format used by the PDF form
 // This preserves the exact same logic as the original GeneratePDFsFromDelegateArbiters function
-func MapDataToFields(pdfData data.PDFData, mapping FieldMapping) map[string]string {
+// The match date/time is rendered with dateFormat (see FormatMatchDateTime); values that cannot be parsed are kept as entered.
+func MapDataToFields(pdfData data.PDFData, mapping FieldMapping, dateFormat string) map[string]string {
 	stringData := make(map[string]string)
 
 	// Extract arbiter data - same logic as original lines 104-114
@@ -57,6 +58,9 @@ func MapDataToFields(pdfData data.PDFData, mapping FieldMapping) map[string]stri
 	stringData[mapping.HomeTeam] = pdfData.Match.HomeTeam
 	stringData[mapping.GuestTeam] = pdfData.Match.GuestTeam
 	stringData[mapping.DateTime] = pdfData.Match.DateTime
+	if startsAt, err := data.ParseDateTime(pdfData.Match.DateTime); err == nil {
+		stringData[mapping.DateTime] = FormatMatchDateTime(startsAt, dateFormat)
+	}
 	stringData[mapping.Address] = pdfData.Match.Address
 
 	// Extract director data - same logic as original lines 149-153
@@ -0,0 +1,162 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// MetadataDefaults configures the document properties of a template.
+// Title and Subject are patterns using the same placeholders as file names (see FileNameValues).
+type MetadataDefaults struct {
+	Title    string   `json:"title"`    // Title pattern
+	Subject  string   `json:"subject"`  // Subject pattern
+	Author   string   `json:"author"`   // Author of the documents
+	Creator  string   `json:"creator"`  // Application that created the documents
+	Keywords []string `json:"keywords"` // Keywords added to every document
+}
+
+// DefaultMetadata provides the document properties for the league delegation template.
+var DefaultMetadata = MetadataDefaults{
+	Title:    "Delegácia – {league} – {home} vs {guest}",
+	Subject:  "Delegačný list rozhodcu, sezóna {season}",
+	Author:   "Slovenský šachový zväz",
+	Creator:  "Chess Arbiter Delegation Generator",
+	Keywords: []string{"delegácia", "rozhodca"},
+}
+
+// DocumentMetadata holds the resolved properties of one generated document.
+type DocumentMetadata struct {
+	Title          string
+	Subject        string
+	Author         string
+	Creator        string
+	Keywords       []string
+	Season         string
+	Arbiter        string
+	DocumentNumber string
+	MatchID        string
+}
+
+// BuildDocumentMetadata resolves the template's metadata defaults for a single delegation.
+// The season, arbiter and document number are always added as keywords so documents can be searched by them.
+func BuildDocumentMetadata(pdfData data.PDFData, defaults MetadataDefaults) DocumentMetadata {
+	values := FileNameValues(pdfData, pdfData.DocumentNumber)
+	arbiter := strings.TrimSpace(pdfData.Arbiter.FirstName + " " + pdfData.Arbiter.LastName)
+
+	var keywords []string
+	for _, keyword := range append(append([]string{}, defaults.Keywords...), pdfData.League.Name, pdfData.League.Year, arbiter, pdfData.DocumentNumber) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	return DocumentMetadata{
+		Title:          strings.TrimSpace(expandPattern(defaults.Title, values)),
+		Subject:        strings.TrimSpace(expandPattern(defaults.Subject, values)),
+		Author:         defaults.Author,
+		Creator:        defaults.Creator,
+		Keywords:       keywords,
+		Season:         pdfData.League.Year,
+		Arbiter:        arbiter,
+		DocumentNumber: pdfData.DocumentNumber,
+		MatchID:        pdfData.Match.MatchID,
+	}
+}
+
+// applyMetadata writes the document properties into the info dictionary and as an XMP metadata stream.
+func applyMetadata(ctx *model.Context, metadata DocumentMetadata) error {
+	properties := map[string]string{
+		"Title":          metadata.Title,
+		"Subject":        metadata.Subject,
+		"Author":         metadata.Author,
+		"Creator":        metadata.Creator,
+		"Keywords":       strings.Join(metadata.Keywords, ", "),
+		"Season":         metadata.Season,
+		"Arbiter":        metadata.Arbiter,
+		"DocumentNumber": metadata.DocumentNumber,
+		"MatchID":        metadata.MatchID,
+	}
+	for key, value := range properties {
+		if value == "" {
+			delete(properties, key)
+		}
+	}
+
+	if err := pdfcpu.PropertiesAdd(ctx, properties); err != nil {
+		return fmt.Errorf("failed to set document properties: %v", err)
+	}
+
+	return setXMPMetadata(ctx, buildXMP(metadata, time.Now()))
+}
+
+// setXMPMetadata replaces the catalog's metadata stream with the given XMP packet.
+// The stream is left uncompressed, as recommended for metadata, so indexers can read it directly.
+func setXMPMetadata(ctx *model.Context, xmp []byte) error {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return fmt.Errorf("failed to read PDF catalog: %v", err)
+	}
+
+	sd := types.StreamDict{Dict: types.NewDict(), Content: xmp}
+	sd.InsertName("Type", "Metadata")
+	sd.InsertName("Subtype", "XML")
+	if err := sd.Encode(); err != nil {
+		return fmt.Errorf("failed to encode XMP metadata: %v", err)
+	}
+
+	indRef, err := ctx.IndRefForNewObject(sd)
+	if err != nil {
+		return fmt.Errorf("failed to add XMP metadata: %v", err)
+	}
+
+	rootDict.Update("Metadata", *indRef)
+	return nil
+}
+
+// buildXMP renders the metadata as an XMP packet with Dublin Core, PDF and XMP basic properties.
+func buildXMP(metadata DocumentMetadata, now time.Time) []byte {
+	escape := func(s string) string {
+		var b bytes.Buffer
+		xml.EscapeText(&b, []byte(s))
+		return b.String()
+	}
+
+	var keywordItems strings.Builder
+	for _, keyword := range metadata.Keywords {
+		fmt.Fprintf(&keywordItems, "<rdf:li>%s</rdf:li>", escape(keyword))
+	}
+
+	timestamp := now.Format(time.RFC3339)
+
+	var b bytes.Buffer
+	b.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString(`<x:xmpmeta xmlns:x="adobe:ns:meta/">
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description rdf:about=""
+ xmlns:dc="http://purl.org/dc/elements/1.1/"
+ xmlns:pdf="http://ns.adobe.com/pdf/1.3/"
+ xmlns:xmp="http://ns.adobe.com/xap/1.0/">
+`)
+	fmt.Fprintf(&b, "<dc:format>application/pdf</dc:format>\n")
+	fmt.Fprintf(&b, "<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", escape(metadata.Title))
+	fmt.Fprintf(&b, "<dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", escape(metadata.Subject))
+	fmt.Fprintf(&b, "<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", escape(metadata.Author))
+	fmt.Fprintf(&b, "<dc:subject><rdf:Bag>%s</rdf:Bag></dc:subject>\n", keywordItems.String())
+	fmt.Fprintf(&b, "<pdf:Keywords>%s</pdf:Keywords>\n", escape(strings.Join(metadata.Keywords, ", ")))
+	fmt.Fprintf(&b, "<xmp:CreatorTool>%s</xmp:CreatorTool>\n", escape(metadata.Creator))
+	fmt.Fprintf(&b, "<xmp:CreateDate>%s</xmp:CreateDate>\n", timestamp)
+	fmt.Fprintf(&b, "<xmp:ModifyDate>%s</xmp:ModifyDate>\n", timestamp)
+	fmt.Fprintf(&b, "<xmp:MetadataDate>%s</xmp:MetadataDate>\n", timestamp)
+	b.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n")
+	b.WriteString("<?xpacket end=\"w\"?>")
+
+	return b.Bytes()
+}
@@ -0,0 +1,177 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultFileNamePattern is used when a template does not define its own naming scheme.
+const DefaultFileNamePattern = "{number}_{lastname}_{firstname}"
+
+// fallbackFileName is used when the pattern resolves to an empty name.
+const fallbackFileName = "delegacia"
+
+// maxFileNameLength limits the base name (without extension) to keep ZIP tools and file systems happy.
+const maxFileNameLength = 120
+
+// specialTransliterations covers letters that do not decompose into an ASCII base letter.
+var specialTransliterations = map[rune]string{
+	'ß': "ss", 'ø': "o", 'Ø': "O", 'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D",
+	'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+}
+
+// FileNameValues returns the placeholder values available to file name and metadata patterns.
+// Supported placeholders are {round}, {date}, {home}, {guest}, {arbiter},
+// {lastname}, {firstname}, {playerid}, {role}, {number}, {matchid}, {league} and {season}.
+// {role} is empty for matches with a single arbiter.
+func FileNameValues(pdfData data.PDFData, documentNumber string) map[string]string {
+	round := ""
+	if pdfData.Match.Round > 0 {
+		round = strconv.Itoa(pdfData.Match.Round)
+	}
+
+	// Only the date part of "2025/10/25 11:00" is used, the time would just add noise
+	date := strings.TrimSpace(pdfData.Match.DateTime)
+	if fields := strings.Fields(date); len(fields) > 0 {
+		date = strings.ReplaceAll(fields[0], "/", "-")
+	}
+
+	arbiter := strings.TrimSpace(pdfData.Arbiter.LastName + " " + pdfData.Arbiter.FirstName)
+
+	role := ""
+	if pdfData.Role != "" {
+		role = data.RoleLabel(pdfData.Role)
+	}
+
+	return map[string]string{
+		"round":     round,
+		"date":      date,
+		"home":      pdfData.Match.HomeTeam,
+		"guest":     pdfData.Match.GuestTeam,
+		"arbiter":   arbiter,
+		"lastname":  pdfData.Arbiter.LastName,
+		"firstname": pdfData.Arbiter.FirstName,
+		"playerid":  pdfData.Arbiter.PlayerID,
+		"role":      role,
+		"number":    documentNumber,
+		"matchid":   pdfData.Match.MatchID,
+		"league":    pdfData.League.Name,
+		"season":    pdfData.League.Year,
+	}
+}
+
+// expandPattern replaces {placeholders} in pattern with the given values as they are.
+func expandPattern(pattern string, values map[string]string) string {
+	var replacements []string
+	for key, value := range values {
+		replacements = append(replacements, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(pattern)
+}
+
+// BuildFileName resolves the pattern with the given values and returns a sanitised base name without extension.
+// Every value is transliterated to ASCII and stripped of path separators before it is inserted.
+func BuildFileName(pattern string, values map[string]string) string {
+	if pattern == "" {
+		pattern = DefaultFileNamePattern
+	}
+
+	sanitized := make(map[string]string, len(values))
+	for key, value := range values {
+		sanitized[key] = sanitizeFileNamePart(value)
+	}
+	name := expandPattern(pattern, sanitized)
+
+	// The pattern itself may contain characters we don't want either
+	name = sanitizeFileNamePart(name)
+	name = collapseSeparators(name)
+
+	if len(name) > maxFileNameLength {
+		name = strings.TrimRight(name[:maxFileNameLength], "_-.")
+	}
+	if name == "" {
+		name = fallbackFileName
+	}
+	return name
+}
+
+// Transliterate converts text to plain ASCII, dropping diacritics ("Štefánik" -> "Stefanik").
+func Transliterate(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if replacement, ok := specialTransliterations[r]; ok {
+			b.WriteString(replacement)
+			continue
+		}
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if r <= unicode.MaxASCII {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// sanitizeFileNamePart transliterates a value and replaces anything that is not safe in a file name with "_".
+func sanitizeFileNamePart(s string) string {
+	s = Transliterate(strings.TrimSpace(s))
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			// Spaces, path separators and any other punctuation
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// collapseSeparators removes repeated and leading/trailing separators left behind by empty placeholders.
+func collapseSeparators(s string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range s {
+		isSep := r == '_' || r == '-' || r == '.'
+		if isSep && (prev == '_' || prev == '-' || prev == '.') {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return strings.Trim(b.String(), "_-.")
+}
+
+// reserveUniquePath creates an empty file for the first free "<name>.pdf", "<name>_2.pdf", ... in dir.
+// The file is created exclusively so concurrent generations never overwrite each other.
+// Returns the reserved path.
+func reserveUniquePath(dir string, baseName string) (string, error) {
+	for i := 1; i < 10000; i++ {
+		name := baseName
+		if i > 1 {
+			name = fmt.Sprintf("%s_%d", baseName, i)
+		}
+		path := filepath.Join(dir, name+".pdf")
+
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return path, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("failed to reserve output file %s: %v", path, err)
+		}
+	}
+	return "", fmt.Errorf("could not find a free file name for %s", baseName)
+}
@@ -0,0 +1,93 @@
+package pdf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildFileName(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		values  map[string]string
+		want    string
+	}{
+		{
+			name:    "default pattern",
+			pattern: "",
+			values:  map[string]string{"number": "12", "lastname": "Štefánik", "firstname": "Ján"},
+			want:    "12_Stefanik_Jan",
+		},
+		{
+			name:    "spaces in values and pattern",
+			pattern: "Delegácia {round}. kolo {home} - {guest}",
+			values:  map[string]string{"round": "3", "home": "ŠK Prievidza", "guest": "ŠKŠ Dubnica B"},
+			want:    "Delegacia_3.kolo_SK_Prievidza_SKS_Dubnica_B",
+		},
+		{
+			name:    "path separators are removed",
+			pattern: "{home}",
+			values:  map[string]string{"home": "ŠK ../Modra\\x"},
+			want:    "SK_Modra_x",
+		},
+		{
+			name:    "empty placeholders leave no separators behind",
+			pattern: "{round}_{date}_{role}_{lastname}",
+			values:  map[string]string{"round": "", "date": "2025-10-25", "role": "", "lastname": "Novák"},
+			want:    "2025-10-25_Novak",
+		},
+		{
+			name:    "unknown placeholders are kept as text",
+			pattern: "{number}_{unknown}",
+			values:  map[string]string{"number": "7"},
+			want:    "7_unknown",
+		},
+		{
+			name:    "empty result falls back",
+			pattern: "{lastname}",
+			values:  map[string]string{"lastname": "日本"},
+			want:    fallbackFileName,
+		},
+		{
+			name:    "long names are shortened",
+			pattern: "{home}",
+			values:  map[string]string{"home": strings.Repeat("a", 200)},
+			want:    strings.Repeat("a", maxFileNameLength),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildFileName(tt.pattern, tt.values); got != tt.want {
+				t.Errorf("BuildFileName(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Štefánik", "Stefanik"},
+		{"Ľubomír Čaplovič", "Lubomir Caplovic"},
+		{"ŠKŠ Dubnica B", "SKS Dubnica B"},
+		{"Ťažký dôchodok", "Tazky dochodok"},
+		{"Straße", "Strasse"},
+		{"Łódź", "Lodz"},
+		{"Øresund", "Oresund"},
+		{"Cœur", "Coeur"},
+		{"日本", ""},
+		{"plain text 123", "plain text 123"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Transliterate(tt.input); got != tt.want {
+				t.Errorf("Transliterate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
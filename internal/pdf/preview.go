@@ -0,0 +1,76 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// PreviewWatermark is stamped across every page of a preview.
+const PreviewWatermark = "NÁHĽAD"
+
+// previewWatermarkDescription configures the look of the preview watermark (pdfcpu watermark syntax).
+const previewWatermarkDescription = "font:Helvetica, points:96, scale:0.9 rel, rot:45, fillcolor:#C00000, op:0.25"
+
+// coreFontFallbacks replaces letters the standard PDF fonts (WinAnsi) cannot draw with their typewriter form.
+var coreFontFallbacks = strings.NewReplacer(
+	"Ľ", "L’", "ľ", "l’", "Ť", "T’", "ť", "t’", "Ď", "D’", "ď", "d’",
+)
+
+// GeneratePreviewPDF fills the template for a single delegation and returns the PDF bytes.
+// The preview carries a "NÁHĽAD" watermark, is never encrypted and is never written to disk.
+// The returned file name is derived from the template's naming scheme.
+// A match with several arbiters is previewed with the first of its letters.
+func GeneratePreviewPDF(pdfData data.PDFData, template TemplateConfig) ([]byte, string, error) {
+	if err := validateTemplate(template.Path); err != nil {
+		return nil, "", err
+	}
+	if err := validatePDFData(pdfData); err != nil {
+		return nil, "", fmt.Errorf("validation failed: %v", err)
+	}
+	pdfData = ExpandOfficials([]data.PDFData{pdfData}, template.RoleFields)[0]
+
+	ctx, err := fillMatchTemplate(pdfData, template)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := applyMetadata(ctx, BuildDocumentMetadata(pdfData, template.Metadata)); err != nil {
+		return nil, "", fmt.Errorf("error setting metadata: %v", err)
+	}
+
+	if err := addPreviewWatermark(ctx); err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	if err := api.WriteContext(ctx, &buf); err != nil {
+		return nil, "", fmt.Errorf("error writing preview PDF: %v", err)
+	}
+
+	fileName := BuildFileName(template.FileNamePattern, FileNameValues(pdfData, pdfData.DocumentNumber)) + "_nahlad.pdf"
+
+	logger.Debug("Generated preview %s (%d bytes)", fileName, buf.Len())
+	return buf.Bytes(), fileName, nil
+}
+
+// addPreviewWatermark stamps the preview watermark over all pages.
+func addPreviewWatermark(ctx *model.Context) error {
+	text := coreFontFallbacks.Replace(PreviewWatermark)
+	wm, err := pdfcpu.ParseTextWatermarkDetails(text, previewWatermarkDescription, true, types.POINTS)
+	if err != nil {
+		return fmt.Errorf("error preparing preview watermark: %v", err)
+	}
+
+	if err := pdfcpu.AddWatermarks(ctx, nil, wm); err != nil {
+		return fmt.Errorf("error adding preview watermark: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// RoleFieldMapping names the form fields a match template offers for matches with several arbiters.
+// The league template has none of them; a template with role fields sets them in its JSON settings file.
+type RoleFieldMapping struct {
+	ArbiterRole   string `json:"arbiterRole"`   // Role of the addressed arbiter
+	ChiefArbiter  string `json:"chiefArbiter"`  // Chief arbiter of the match
+	DeputyArbiter string `json:"deputyArbiter"` // Deputy chief arbiters of the match
+	Arbiters      string `json:"arbiters"`      // Remaining arbiters of the match
+	CoOfficials   string `json:"coOfficials"`   // Arbiters other than the addressed one, with their roles
+}
+
+// HasRoleFields reports whether the template lists all arbiters of a match in one letter.
+func (m RoleFieldMapping) HasRoleFields() bool {
+	return m.ChiefArbiter != ""
+}
+
+// ExpandOfficials turns matches with several arbiters into delegation letters.
+// With role fields there is one letter per match, addressed to the chief arbiter (or the first arbiter);
+// otherwise every arbiter gets a letter of their own that lists the co-officials and has its own number
+// (see data.PDFData.OfficialDocumentNumber).
+// Matches with a single arbiter are kept as they are.
+func ExpandOfficials(pdfDataArray []data.PDFData, roleFields RoleFieldMapping) []data.PDFData {
+	var result []data.PDFData
+	for _, pdfData := range pdfDataArray {
+		if len(pdfData.Officials) == 0 {
+			result = append(result, pdfData)
+			continue
+		}
+
+		if roleFields.HasRoleFields() {
+			addressee := pdfData.Officials[0]
+			if chiefs := data.OfficialsWithRole(pdfData.Officials, data.RoleChiefArbiter); len(chiefs) > 0 {
+				addressee = chiefs[0]
+			}
+			pdfData.Arbiter = addressee.Arbiter
+			pdfData.Role = addressee.Role
+			result = append(result, pdfData)
+			continue
+		}
+
+		for i, official := range pdfData.Officials {
+			letter := pdfData
+			letter.Arbiter = official.Arbiter
+			letter.Role = official.Role
+			letter.DocumentNumber = pdfData.OfficialDocumentNumber(i)
+			result = append(result, letter)
+		}
+	}
+	return result
+}
+
+// describeOfficials lists officials as "Ján Novák (hlavný rozhodca), Eva Malá (rozhodca)".
+func describeOfficials(officials []data.Official) string {
+	var parts []string
+	for _, official := range officials {
+		parts = append(parts, fmt.Sprintf("%s (%s)", official.Arbiter.FullName(), data.RoleLabel(official.Role)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// officialNamesWithRole lists the names of the officials with the given role, see officialName.
+func officialNamesWithRole(officials []data.Official, role string) []string {
+	var names []string
+	for _, official := range data.OfficialsWithRole(officials, role) {
+		names = append(names, officialName(official.Arbiter))
+	}
+	return names
+}
+
+// mapRoleFields adds the role fields of the template to the field data of a match with several arbiters.
+func mapRoleFields(stringData map[string]string, pdfData data.PDFData, roleFields RoleFieldMapping) {
+	if len(pdfData.Officials) == 0 {
+		return
+	}
+
+	values := map[string]string{
+		roleFields.ArbiterRole:   data.RoleLabel(pdfData.Role),
+		roleFields.ChiefArbiter:  strings.Join(officialNamesWithRole(pdfData.Officials, data.RoleChiefArbiter), ", "),
+		roleFields.DeputyArbiter: strings.Join(officialNamesWithRole(pdfData.Officials, data.RoleDeputyArbiter), ", "),
+		roleFields.Arbiters:      strings.Join(officialNamesWithRole(pdfData.Officials, data.RoleArbiter), ", "),
+		roleFields.CoOfficials:   describeOfficials(pdfData.CoOfficials()),
+	}
+	for field, value := range values {
+		if field != "" {
+			stringData[field] = value
+		}
+	}
+}
+
+// roleNote returns the role and co-officials of the addressed arbiter that the template has no fields for,
+// e.g. "Funkcia: rozhodca. Ďalší rozhodcovia: Ján Novák (hlavný rozhodca)". Empty when nothing is missing.
+func roleNote(pdfData data.PDFData, roleFields RoleFieldMapping) string {
+	if len(pdfData.Officials) == 0 || roleFields.HasRoleFields() {
+		return ""
+	}
+
+	var parts []string
+	if roleFields.ArbiterRole == "" && pdfData.Role != "" {
+		parts = append(parts, "Funkcia: "+data.RoleLabel(pdfData.Role))
+	}
+	if coOfficials := pdfData.CoOfficials(); roleFields.CoOfficials == "" && len(coOfficials) > 0 {
+		parts = append(parts, "Ďalší rozhodcovia: "+describeOfficials(coOfficials))
+	}
+	return strings.Join(parts, ". ")
+}
@@ -0,0 +1,59 @@
+package pdf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// DefaultTemplatePath is the PDF template used for league delegations.
+const DefaultTemplatePath = "templates/delegacny_list_ligy.pdf"
+
+// TemplateConfig describes a PDF template together with everything that may differ between templates.
+// Optional settings can be stored in a JSON file next to the template (same name, ".json" extension).
+type TemplateConfig struct {
+	Path              string                 `json:"-"`               // Path to the PDF template
+	Mapping           FieldMapping           `json:"-"`               // Form field names used by match templates
+	TournamentMapping TournamentFieldMapping `json:"-"`               // Form field names used by tournament templates
+	RoleFields        RoleFieldMapping       `json:"roleFields"`      // Optional fields for matches with several arbiters
+	MatchListField    string                 `json:"matchListField"`  // Optional field listing the matches of a venue-grouped delegation
+	FileNamePattern   string                 `json:"fileNamePattern"` // Output file name pattern, see FileNameValues for placeholders
+	DateFormat        string                 `json:"dateFormat"`      // Match date/time format, see FormatMatchDateTime for placeholders
+	Metadata          MetadataDefaults       `json:"metadata"`        // Document properties written into every generated PDF
+	Encryption        EncryptionSettings     `json:"encryption"`      // Optional password protection
+}
+
+// DefaultTemplate is the configuration of the league delegation template.
+var DefaultTemplate = TemplateConfig{
+	Path:            DefaultTemplatePath,
+	Mapping:         DefaultFieldMapping,
+	FileNamePattern: DefaultFileNamePattern,
+	DateFormat:      DefaultDateFormat,
+	Metadata:        DefaultMetadata,
+}
+
+// LoadTemplateConfig returns the configuration for the template at templatePath.
+// It starts from base and overrides the values found in the template's JSON settings file, if there is one.
+func LoadTemplateConfig(templatePath string, base TemplateConfig) (TemplateConfig, error) {
+	config := base
+	config.Path = templatePath
+
+	settingsPath := strings.TrimSuffix(templatePath, ".pdf") + ".json"
+	content, err := os.ReadFile(settingsPath)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("failed to read template settings %s: %v", settingsPath, err)
+	}
+
+	if err := json.Unmarshal(content, &config); err != nil {
+		return config, fmt.Errorf("failed to parse template settings %s: %v", settingsPath, err)
+	}
+
+	logger.Debug("Loaded template settings from %s", settingsPath)
+	return config, nil
+}
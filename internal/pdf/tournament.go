@@ -0,0 +1,219 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// TournamentTemplatePath is the PDF template used for individual tournament delegations.
+const TournamentTemplatePath = "templates/delegacny_list_turnaja.pdf"
+
+// DefaultTournamentFileNamePattern names tournament delegations, see TournamentFileNameValues for placeholders.
+const DefaultTournamentFileNamePattern = "{number}_{tournament}_{season}"
+
+// DefaultTournamentDateFormat renders tournament days like "25. 10. 2025".
+const DefaultTournamentDateFormat = "{day}. {month}. {year}"
+
+// TournamentFieldMapping defines the mapping between tournament data and PDF form fields.
+type TournamentFieldMapping struct {
+	Tournament      string // Tournament name and season
+	DateRange       string // First and last day of the tournament
+	Venue           string // Venue address
+	ChiefArbiter    string // Chief arbiter
+	DeputyArbiter   string // Deputy chief arbiters
+	Arbiters        string // Other arbiters, one per line
+	DirectorContact string // Tournament director
+	ContactPerson   string // Contact person
+}
+
+// DefaultTournamentFieldMapping provides the field mapping for the tournament template.
+var DefaultTournamentFieldMapping = TournamentFieldMapping{
+	Tournament:      "tournament",
+	DateRange:       "date_range",
+	Venue:           "venue",
+	ChiefArbiter:    "chief_arbiter",
+	DeputyArbiter:   "deputy_arbiter",
+	Arbiters:        "arbiters",
+	DirectorContact: "director",
+	ContactPerson:   "contact_person",
+}
+
+// DefaultTournamentMetadata provides the document properties for the tournament template.
+var DefaultTournamentMetadata = MetadataDefaults{
+	Title:    "Delegácia – {tournament}",
+	Subject:  "Delegačný list rozhodcov, sezóna {season}",
+	Author:   "Slovenský šachový zväz",
+	Creator:  "Chess Arbiter Delegation Generator",
+	Keywords: []string{"delegácia", "rozhodca", "turnaj"},
+}
+
+// DefaultTournamentTemplate is the configuration of the tournament delegation template.
+var DefaultTournamentTemplate = TemplateConfig{
+	Path:              TournamentTemplatePath,
+	TournamentMapping: DefaultTournamentFieldMapping,
+	FileNamePattern:   DefaultTournamentFileNamePattern,
+	DateFormat:        DefaultTournamentDateFormat,
+	Metadata:          DefaultTournamentMetadata,
+}
+
+// FormatDateRange renders the tournament days with dateFormat, e.g. "25. 10. 2025 – 27. 10. 2025".
+// A one-day tournament is rendered as a single date.
+func FormatDateRange(start, end time.Time, dateFormat string) string {
+	first := FormatMatchDateTime(start, dateFormat)
+	last := FormatMatchDateTime(end, dateFormat)
+	if first == last {
+		return first
+	}
+	return first + " – " + last
+}
+
+// officialName returns the arbiter's name followed by the player ID, e.g. "Ján Novák (12345)".
+func officialName(arbiter data.ArbiterData) string {
+	if arbiter.PlayerID == "" {
+		return arbiter.FullName()
+	}
+	return fmt.Sprintf("%s (%s)", arbiter.FullName(), arbiter.PlayerID)
+}
+
+// officialNames returns the names of the officials with the given role, see officialName.
+func officialNames(pdfData data.TournamentPDFData, role string) []string {
+	var names []string
+	for _, official := range pdfData.OfficialsWithRole(role) {
+		names = append(names, officialName(official.Arbiter))
+	}
+	return names
+}
+
+// tournamentDates parses the first and last day of the tournament; a missing end date means a one-day tournament.
+func tournamentDates(tournament data.TournamentData) (time.Time, time.Time, error) {
+	start, err := data.ParseDate(tournament.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if tournament.EndDate == "" {
+		return start, start, nil
+	}
+	end, err := data.ParseDate(tournament.EndDate)
+	return start, end, err
+}
+
+// MapTournamentDataToFields converts TournamentPDFData to the form fields of the tournament template.
+// The chief and deputy arbiters go to their own fields, the remaining arbiters are listed one per line.
+func MapTournamentDataToFields(pdfData data.TournamentPDFData, mapping TournamentFieldMapping, dateFormat string) map[string]string {
+	stringData := make(map[string]string)
+
+	tournament := strings.TrimSpace(pdfData.Tournament.Name + " " + pdfData.Tournament.Year)
+	stringData[mapping.Tournament] = tournament
+
+	stringData[mapping.DateRange] = strings.TrimSpace(pdfData.Tournament.StartDate + " – " + pdfData.Tournament.EndDate)
+	if start, end, err := tournamentDates(pdfData.Tournament); err == nil {
+		stringData[mapping.DateRange] = FormatDateRange(start, end, dateFormat)
+	}
+	stringData[mapping.Venue] = pdfData.Tournament.Venue
+
+	stringData[mapping.ChiefArbiter] = strings.Join(officialNames(pdfData, data.RoleChiefArbiter), ", ")
+	stringData[mapping.DeputyArbiter] = strings.Join(officialNames(pdfData, data.RoleDeputyArbiter), ", ")
+	stringData[mapping.Arbiters] = strings.Join(officialNames(pdfData, data.RoleArbiter), "\n")
+
+	stringData[mapping.DirectorContact] = pdfData.Director.Contact
+	stringData[mapping.ContactPerson] = pdfData.ContactPerson
+
+	return stringData
+}
+
+// TournamentFileNameValues returns the placeholder values available to tournament file name and metadata patterns.
+// Supported placeholders are {tournament}, {season}, {date} (first day), {chief} (chief arbiter's surname) and {number}.
+func TournamentFileNameValues(pdfData data.TournamentPDFData, documentNumber string) map[string]string {
+	date := ""
+	if start, err := data.ParseDate(pdfData.Tournament.StartDate); err == nil {
+		date = start.Format("2006-01-02")
+	}
+
+	chief := ""
+	if chiefs := pdfData.OfficialsWithRole(data.RoleChiefArbiter); len(chiefs) > 0 {
+		chief = chiefs[0].Arbiter.LastName
+	}
+
+	return map[string]string{
+		"tournament": pdfData.Tournament.Name,
+		"season":     pdfData.Tournament.Year,
+		"date":       date,
+		"chief":      chief,
+		"number":     documentNumber,
+	}
+}
+
+// BuildTournamentMetadata resolves the template's metadata defaults for a tournament delegation.
+// Every delegated arbiter is added as a keyword.
+func BuildTournamentMetadata(pdfData data.TournamentPDFData, defaults MetadataDefaults) DocumentMetadata {
+	values := TournamentFileNameValues(pdfData, pdfData.DocumentNumber)
+
+	var arbiters []string
+	for _, official := range pdfData.Officials {
+		arbiters = append(arbiters, official.Arbiter.FullName())
+	}
+
+	var keywords []string
+	candidates := append(append(append([]string{}, defaults.Keywords...), pdfData.Tournament.Name, pdfData.Tournament.Year), arbiters...)
+	for _, keyword := range append(candidates, pdfData.DocumentNumber) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	return DocumentMetadata{
+		Title:          strings.TrimSpace(expandPattern(defaults.Title, values)),
+		Subject:        strings.TrimSpace(expandPattern(defaults.Subject, values)),
+		Author:         defaults.Author,
+		Creator:        defaults.Creator,
+		Keywords:       keywords,
+		Season:         pdfData.Tournament.Year,
+		Arbiter:        strings.Join(arbiters, ", "),
+		DocumentNumber: pdfData.DocumentNumber,
+	}
+}
+
+// GenerateTournamentPDF fills the tournament template with one delegation covering all of its officials.
+// Returns the path to the generated PDF in the results directory.
+func GenerateTournamentPDF(pdfData data.TournamentPDFData, template TemplateConfig) (string, error) {
+	if err := validateTemplate(template.Path); err != nil {
+		return "", err
+	}
+	if err := pdfData.Validate(); err != nil {
+		return "", fmt.Errorf("validation failed: %v", err)
+	}
+
+	if pdfData.DocumentNumber == "" {
+		pdfData.DocumentNumber = "001"
+	}
+	pdfData.Officials = append([]data.Official(nil), pdfData.Officials...)
+	data.SortOfficials(pdfData.Officials)
+
+	fieldData := MapTournamentDataToFields(pdfData, template.TournamentMapping, template.DateFormat)
+
+	ctx, err := fillFormContext(template.Path, fieldData)
+	if err != nil {
+		return "", err
+	}
+
+	if err := applyMetadata(ctx, BuildTournamentMetadata(pdfData, template.Metadata)); err != nil {
+		return "", fmt.Errorf("error setting metadata: %v", err)
+	}
+
+	values := TournamentFileNameValues(pdfData, pdfData.DocumentNumber)
+	if err := applyEncryption(ctx, template.Encryption, values); err != nil {
+		return "", fmt.Errorf("error setting encryption: %v", err)
+	}
+
+	outputPath, err := writeResultFile(ctx, BuildFileName(template.FileNamePattern, values))
+	if err != nil {
+		return "", err
+	}
+
+	logger.Info("Generated tournament delegation %s with %d arbiters", outputPath, len(pdfData.Officials))
+	return outputPath, nil
+}
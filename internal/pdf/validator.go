@@ -3,6 +3,7 @@ package pdf
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
 )
@@ -15,7 +16,87 @@ func validateTemplate(templatePath string) error {
 	return nil
 }
 
+// InvalidItem names a delegation of a batch that cannot be generated.
+type InvalidItem struct {
+	Index     int    `json:"index"`     // Position of the delegation in the request
+	HomeTeam  string `json:"homeTeam"`  // Home team of the delegated match
+	GuestTeam string `json:"guestTeam"` // Guest team of the delegated match
+	Error     string `json:"error"`     // Why the delegation was rejected
+}
+
+// ValidateBatch checks every delegation of a batch before anything is generated.
+// Returns the delegations that would fail, so all of them can be fixed at once.
+func ValidateBatch(pdfDataArray []data.PDFData) []InvalidItem {
+	var invalid []InvalidItem
+	for i, pdfData := range pdfDataArray {
+		if err := validatePDFData(pdfData); err != nil {
+			invalid = append(invalid, InvalidItem{
+				Index:     i,
+				HomeTeam:  pdfData.Match.HomeTeam,
+				GuestTeam: pdfData.Match.GuestTeam,
+				Error:     err.Error(),
+			})
+		}
+	}
+	return invalid
+}
+
 // validatePDFData checks if the PDFData has all required fields
+// and match date/times that can be parsed, so no delegation is issued with a blank or garbled time.
+// A date/time marked as FreeDateTime is printed as entered and only has to be non-empty.
+// The matches of a venue-grouped delegation must share the venue and start, see validateVenueGroup.
 func validatePDFData(pdfData data.PDFData) error {
-	return pdfData.Validate()
+	if err := pdfData.Validate(); err != nil {
+		return err
+	}
+	for _, match := range append([]data.MatchData{pdfData.Match}, pdfData.Matches...) {
+		if match.FreeDateTime {
+			if strings.TrimSpace(match.DateTime) == "" {
+				return fmt.Errorf("match %s vs %s: date and time are missing", match.HomeTeam, match.GuestTeam)
+			}
+			continue
+		}
+		if _, err := data.ParseDateTime(match.DateTime); err != nil {
+			return fmt.Errorf("match %s vs %s: %v", match.HomeTeam, match.GuestTeam, err)
+		}
+	}
+	return validateVenueGroup(pdfData)
+}
+
+// validateVenueGroup checks that a venue-grouped delegation starts with Match and that all of its matches
+// are played in the same hall at the same time, as the letter only prints the date, time and venue of Match.
+func validateVenueGroup(pdfData data.PDFData) error {
+	if len(pdfData.Matches) == 0 {
+		return nil
+	}
+	first := pdfData.Matches[0]
+	if first != pdfData.Match {
+		return fmt.Errorf("match %s vs %s must be the first of the grouped matches", pdfData.Match.HomeTeam, pdfData.Match.GuestTeam)
+	}
+	for _, match := range pdfData.Matches[1:] {
+		if !sameText(match.Address, first.Address) {
+			return fmt.Errorf("match %s vs %s is played at %q, not at %q like the other grouped matches",
+				match.HomeTeam, match.GuestTeam, match.Address, first.Address)
+		}
+		if !sameDateTime(match, first) {
+			return fmt.Errorf("match %s vs %s starts at %s, not at %s like the other grouped matches",
+				match.HomeTeam, match.GuestTeam, match.DateTime, first.DateTime)
+		}
+	}
+	return nil
+}
+
+// sameText reports whether two texts are equal, ignoring case and repeated spaces.
+func sameText(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
+
+// sameDateTime reports whether two matches start at the same time; free-text date/times are compared as text.
+func sameDateTime(a, b data.MatchData) bool {
+	if a.FreeDateTime || b.FreeDateTime {
+		return sameText(a.DateTime, b.DateTime)
+	}
+	startA, errA := data.ParseDateTime(a.DateTime)
+	startB, errB := data.ParseDateTime(b.DateTime)
+	return errA == nil && errB == nil && startA.Equal(startB)
 }
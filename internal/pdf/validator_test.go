@@ -0,0 +1,119 @@
+package pdf
+
+import (
+	"reflect"
+	"testing"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// validDelegation returns a delegation that passes validation, for the tests to break.
+func validDelegation(home, guest string) data.PDFData {
+	return data.PDFData{
+		Arbiter:  data.ArbiterData{FirstName: "Ján", LastName: "Novák", PlayerID: "111"},
+		League:   data.LeagueData{Name: "1. liga", Year: "2025/2026"},
+		Match:    data.MatchData{Round: 1, HomeTeam: home, GuestTeam: guest, DateTime: "2025/10/25 11:00", Address: "Hall A"},
+		Director: data.DirectorData{Contact: "director@example.com"},
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	withoutLeague := validDelegation("ŠK Modra", "TJ Slávia")
+	withoutLeague.League.Name = ""
+
+	withoutDirector := validDelegation("ŠK Modra", "TJ Slávia")
+	withoutDirector.Director.Contact = ""
+
+	badDate := validDelegation("ŠK Modra", "TJ Slávia")
+	badDate.Match.DateTime = "2025/13/45 11:00"
+
+	missingTime := validDelegation("ŠK Modra", "TJ Slávia")
+	missingTime.Match.DateTime = "2025/10/25"
+
+	freeText := validDelegation("ŠK Modra", "TJ Slávia")
+	freeText.Match.DateTime, freeText.Match.FreeDateTime = "podľa dohody", true
+
+	emptyFreeText := validDelegation("ŠK Modra", "TJ Slávia")
+	emptyFreeText.Match.DateTime, emptyFreeText.Match.FreeDateTime = " ", true
+
+	unknownRole := validDelegation("ŠK Modra", "TJ Slávia")
+	unknownRole.Role = "referee"
+
+	twoChiefs := validDelegation("ŠK Modra", "TJ Slávia")
+	twoChiefs.Officials = []data.Official{
+		{Arbiter: data.ArbiterData{LastName: "Novák"}, Role: data.RoleChiefArbiter},
+		{Arbiter: data.ArbiterData{LastName: "Kováč"}, Role: data.RoleChiefArbiter},
+	}
+
+	officials := validDelegation("ŠK Modra", "TJ Slávia")
+	officials.Officials = []data.Official{
+		{Arbiter: data.ArbiterData{LastName: "Novák"}, Role: data.RoleChiefArbiter},
+		{Arbiter: data.ArbiterData{LastName: "Kováč"}, Role: data.RoleArbiter},
+	}
+
+	group := validDelegation("ŠK Modra", "TJ Slávia")
+	second := group.Match
+	second.HomeTeam, second.GuestTeam = "ŠK Modra B", "TJ Slávia B"
+	group.Matches = []data.MatchData{group.Match, second}
+
+	groupOtherVenue := validDelegation("ŠK Modra", "TJ Slávia")
+	otherVenue := second
+	otherVenue.Address = "Hall B"
+	groupOtherVenue.Matches = []data.MatchData{groupOtherVenue.Match, otherVenue}
+
+	groupOtherStart := validDelegation("ŠK Modra", "TJ Slávia")
+	otherStart := second
+	otherStart.DateTime = "2025/10/25 14:00"
+	groupOtherStart.Matches = []data.MatchData{groupOtherStart.Match, otherStart}
+
+	groupWrongFirst := validDelegation("ŠK Modra", "TJ Slávia")
+	groupWrongFirst.Matches = []data.MatchData{second, groupWrongFirst.Match}
+
+	tests := []struct {
+		name     string
+		batch    []data.PDFData
+		wantBad  []int
+		wantTeam string // Home team reported for the first invalid delegation
+	}{
+		{"valid delegation", []data.PDFData{validDelegation("ŠK Modra", "TJ Slávia")}, nil, ""},
+		{"free-text date and time", []data.PDFData{freeText}, nil, ""},
+		{"several officials", []data.PDFData{officials}, nil, ""},
+		{"venue group", []data.PDFData{group}, nil, ""},
+		{"missing league name", []data.PDFData{withoutLeague}, []int{0}, "ŠK Modra"},
+		{"missing director contact", []data.PDFData{withoutDirector}, []int{0}, "ŠK Modra"},
+		{"invalid date", []data.PDFData{badDate}, []int{0}, "ŠK Modra"},
+		{"date without time", []data.PDFData{missingTime}, []int{0}, "ŠK Modra"},
+		{"empty free-text date and time", []data.PDFData{emptyFreeText}, []int{0}, "ŠK Modra"},
+		{"unknown role", []data.PDFData{unknownRole}, []int{0}, "ŠK Modra"},
+		{"two chief arbiters", []data.PDFData{twoChiefs}, []int{0}, "ŠK Modra"},
+		{"grouped match at another venue", []data.PDFData{groupOtherVenue}, []int{0}, "ŠK Modra"},
+		{"grouped match at another time", []data.PDFData{groupOtherStart}, []int{0}, "ŠK Modra"},
+		{"group not starting with the match", []data.PDFData{groupWrongFirst}, []int{0}, "ŠK Modra"},
+		{
+			name:     "every invalid delegation is reported",
+			batch:    []data.PDFData{validDelegation("ŠK Nitra", "ŠK Modra"), badDate, validDelegation("TJ Slávia", "ŠK Nitra"), withoutDirector},
+			wantBad:  []int{1, 3},
+			wantTeam: "ŠK Modra",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := ValidateBatch(tt.batch)
+
+			var indexes []int
+			for _, item := range invalid {
+				if item.Error == "" {
+					t.Errorf("item %d has no error message", item.Index)
+				}
+				indexes = append(indexes, item.Index)
+			}
+			if !reflect.DeepEqual(indexes, tt.wantBad) {
+				t.Fatalf("invalid indexes = %v, want %v (%+v)", indexes, tt.wantBad, invalid)
+			}
+			if len(invalid) > 0 && invalid[0].HomeTeam != tt.wantTeam {
+				t.Errorf("home team = %q, want %q", invalid[0].HomeTeam, tt.wantTeam)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// matchLines lists the matches of a venue-grouped delegation as "Home – Guest".
+func matchLines(matches []data.MatchData) []string {
+	lines := make([]string, 0, len(matches))
+	for _, match := range matches {
+		lines = append(lines, fmt.Sprintf("%s – %s", match.HomeTeam, match.GuestTeam))
+	}
+	return lines
+}
+
+// mapMatchList lists the matches of a venue-grouped delegation in the template's match list field, one per line.
+// The home and guest team fields are left empty then, as they would only show the first match.
+func mapMatchList(stringData map[string]string, pdfData data.PDFData, template TemplateConfig) {
+	if len(pdfData.Matches) == 0 || template.MatchListField == "" {
+		return
+	}
+	stringData[template.MatchListField] = strings.Join(matchLines(pdfData.Matches), "\n")
+	stringData[template.Mapping.HomeTeam] = ""
+	stringData[template.Mapping.GuestTeam] = ""
+}
+
+// matchesNote lists the matches of a venue-grouped delegation for templates without a match list field,
+// one per line after the heading "Zápasy v hracej miestnosti (2):". Empty for a single match.
+func matchesNote(pdfData data.PDFData, template TemplateConfig) string {
+	if len(pdfData.Matches) == 0 || template.MatchListField != "" {
+		return ""
+	}
+	lines := append([]string{fmt.Sprintf("Zápasy v hracej miestnosti (%d):", len(pdfData.Matches))}, matchLines(pdfData.Matches)...)
+	return strings.Join(lines, "\n")
+}
@@ -11,11 +11,96 @@ import (
 	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
 )
 
+// ZipLayout selects how documents are organised inside the delegation package.
+type ZipLayout string
+
+const (
+	ZipLayoutFlat      ZipLayout = "flat"    // All documents in the root of the archive
+	ZipLayoutByRound   ZipLayout = "round"   // One folder per round
+	ZipLayoutByArbiter ZipLayout = "arbiter" // One folder per arbiter
+)
+
+// ParseZipLayout converts a user supplied layout name to a ZipLayout.
+// An empty name selects the flat layout.
+func ParseZipLayout(name string) (ZipLayout, error) {
+	switch ZipLayout(name) {
+	case "", ZipLayoutFlat:
+		return ZipLayoutFlat, nil
+	case ZipLayoutByRound, ZipLayoutByArbiter:
+		return ZipLayout(name), nil
+	}
+	return "", fmt.Errorf("unknown zip layout: %s", name)
+}
+
+// GeneratedDocument is a filled PDF on disk together with the data it was generated from.
+type GeneratedDocument struct {
+	Path string       // Path of the generated PDF file
+	Data data.PDFData // Data used to fill the document (with DocumentNumber assigned)
+}
+
 func CreateZipFromFiles(pdfFiles []string, zipName string) (string, error) {
+	zipWriter, zipFile, zipPath, err := createZipFile(zipName)
+	if err != nil {
+		return "", err
+	}
+
+	// Add each PDF file to the zip
+	for _, pdfFile := range pdfFiles {
+		// Set the name in the zip to just the filename (not the full path)
+		if err := addFileToZip(zipWriter, pdfFile, filepath.Base(pdfFile)); err != nil {
+			discardZipFile(zipWriter, zipFile, zipPath)
+			return "", err
+		}
+	}
+
+	if err := closeZipFile(zipWriter, zipFile); err != nil {
+		os.Remove(zipPath)
+		return "", err
+	}
+	return zipPath, nil
+}
+
+// CreateZipFromDocuments packages generated documents using the given layout.
+// Besides the PDFs the archive always contains manifest.csv and manifest.json describing every document.
+// Returns the path to the created zip file.
+func CreateZipFromDocuments(documents []GeneratedDocument, zipName string, layout ZipLayout) (string, error) {
+	zipWriter, zipFile, zipPath, err := createZipFile(zipName)
+	if err != nil {
+		return "", err
+	}
+
+	var manifest []ManifestEntry
+	for _, document := range documents {
+		nameInZip := zipEntryName(document, layout)
+		if err := addFileToZip(zipWriter, document.Path, nameInZip); err != nil {
+			discardZipFile(zipWriter, zipFile, zipPath)
+			return "", err
+		}
+		manifest = append(manifest, newManifestEntry(document, nameInZip))
+	}
+
+	if err := writeManifest(zipWriter, manifest); err != nil {
+		discardZipFile(zipWriter, zipFile, zipPath)
+		return "", err
+	}
+
+	// A failed close leaves a truncated archive, so it is reported instead of the path
+	if err := closeZipFile(zipWriter, zipFile); err != nil {
+		os.Remove(zipPath)
+		return "", err
+	}
+
+	logger.Debug("Packaged %d documents into %s using %s layout", len(documents), zipPath, layout)
+	return zipPath, nil
+}
+
+// createZipFile creates a new zip archive in the results directory.
+// The caller finishes it with closeZipFile, or discardZipFile when packaging fails.
+func createZipFile(zipName string) (*zip.Writer, *os.File, string, error) {
 	// Ensure the results directory exists
 	resultsDir := "assets/results"
 	if err := os.MkdirAll(resultsDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create results directory: %v", err)
+		return nil, nil, "", fmt.Errorf("failed to create results directory: %v", err)
 	}
 
 	// Create zip file path
@@ -24,76 +109,120 @@ func CreateZipFromFiles(pdfFiles []string, zipName string) (string, error) {
 	// Create the zip file
 	zipFile, err := os.Create(zipPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to create zip file: %v", err)
+		return nil, nil, "", fmt.Errorf("failed to create zip file: %v", err)
 	}
-	defer zipFile.Close()
 
-	// Create a new zip writer
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+	return zip.NewWriter(zipFile), zipFile, zipPath, nil
+}
 
-	// Add each PDF file to the zip
-	for _, pdfFile := range pdfFiles {
-		// Open the PDF file
-		file, err := os.Open(pdfFile)
-		if err != nil {
-			return "", fmt.Errorf("failed to open PDF file %s: %v", pdfFile, err)
-		}
-		defer file.Close()
+// closeZipFile writes the archive's central directory and closes the file.
+// Returns the first error, in which case the archive on disk is incomplete.
+func closeZipFile(zipWriter *zip.Writer, zipFile *os.File) error {
+	writerErr := zipWriter.Close()
+	fileErr := zipFile.Close()
+	if writerErr != nil {
+		return fmt.Errorf("failed to finish zip file: %v", writerErr)
+	}
+	if fileErr != nil {
+		return fmt.Errorf("failed to close zip file: %v", fileErr)
+	}
+	return nil
+}
 
-		// Get file info for the zip entry
-		fileInfo, err := file.Stat()
-		if err != nil {
-			return "", fmt.Errorf("failed to get file info for %s: %v", pdfFile, err)
-		}
+// discardZipFile closes and removes an archive that could not be completed.
+func discardZipFile(zipWriter *zip.Writer, zipFile *os.File, zipPath string) {
+	zipWriter.Close()
+	zipFile.Close()
+	if err := os.Remove(zipPath); err != nil {
+		logger.Error("Failed to remove incomplete zip file %s: %v", zipPath, err)
+	}
+}
 
-		// Create a zip file header
-		header, err := zip.FileInfoHeader(fileInfo)
-		if err != nil {
-			return "", fmt.Errorf("failed to create zip header for %s: %v", pdfFile, err)
-		}
+// addFileToZip copies a file from disk into the archive under nameInZip.
+func addFileToZip(zipWriter *zip.Writer, filePath string, nameInZip string) error {
+	// Open the PDF file
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open PDF file %s: %v", filePath, err)
+	}
+	defer file.Close()
 
-		// Set the name in the zip to just the filename (not the full path)
-		header.Name = filepath.Base(pdfFile)
-		header.Method = zip.Deflate
+	// Get file info for the zip entry
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to get file info for %s: %v", filePath, err)
+	}
 
-		// Create the zip file entry
-		writer, err := zipWriter.CreateHeader(header)
-		if err != nil {
-			return "", fmt.Errorf("failed to create zip entry for %s: %v", pdfFile, err)
-		}
+	// Create a zip file header
+	header, err := zip.FileInfoHeader(fileInfo)
+	if err != nil {
+		return fmt.Errorf("failed to create zip header for %s: %v", filePath, err)
+	}
+	header.Name = nameInZip
+	header.Method = zip.Deflate
 
-		// Copy the file content to the zip entry
-		_, err = io.Copy(writer, file)
-		if err != nil {
-			return "", fmt.Errorf("failed to copy file %s to zip: %v", pdfFile, err)
-		}
+	// Create the zip file entry
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to create zip entry for %s: %v", filePath, err)
 	}
 
-	return zipPath, nil
+	// Copy the file content to the zip entry
+	if _, err := io.Copy(writer, file); err != nil {
+		return fmt.Errorf("failed to copy file %s to zip: %v", filePath, err)
+	}
+	return nil
+}
+
+// zipEntryName returns the path of a document inside the archive for the given layout.
+// Zip entries always use forward slashes regardless of the operating system.
+func zipEntryName(document GeneratedDocument, layout ZipLayout) string {
+	fileName := filepath.Base(document.Path)
+
+	switch layout {
+	case ZipLayoutByRound:
+		folder := "bez_kola"
+		if document.Data.Match.Round > 0 {
+			folder = fmt.Sprintf("kolo_%02d", document.Data.Match.Round)
+		}
+		return folder + "/" + fileName
+	case ZipLayoutByArbiter:
+		folder := BuildFileName("{arbiter}", FileNameValues(document.Data, ""))
+		if folder == fallbackFileName {
+			folder = "bez_rozhodcu"
+		}
+		return folder + "/" + fileName
+	}
+	return fileName
 }
 
 // GeneratePDFsAndZip generates PDF files and creates a zip file containing all of them
-func GeneratePDFsAndZip(pdfDataArray []data.PDFData, templatePath string, zipName string) (string, error) {
+func GeneratePDFsAndZip(pdfDataArray []data.PDFData, template TemplateConfig, zipName string, layout ZipLayout) (string, error) {
 	// Generate PDFs first
-	generatedFiles, err := GeneratePDFsFromDelegateArbiters(pdfDataArray, templatePath)
+	documents, err := GeneratePDFsFromDelegateArbiters(pdfDataArray, template)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate PDFs: %v", err)
 	}
 
 	// Create zip file
-	zipPath, err := CreateZipFromFiles(generatedFiles, zipName)
-	if err != nil {
-		return "", fmt.Errorf("failed to create zip file: %v", err)
-	}
+	zipPath, err := CreateZipFromDocuments(documents, zipName, layout)
 
 	// Clean up individual PDF files after creating zip
-	for _, file := range generatedFiles {
-		if err := os.Remove(file); err != nil {
-			logger.Error("Failed to remove temporary PDF file %s: %v", file, err)
-		}
+	removeDocuments(documents)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to create zip file: %v", err)
 	}
 
 	logger.Info("Created zip file: %s", zipPath)
 	return zipPath, nil
 }
+
+// removeDocuments deletes generated files, logging (but otherwise ignoring) failures.
+func removeDocuments(documents []GeneratedDocument) {
+	for _, document := range documents {
+		if err := os.Remove(document.Path); err != nil {
+			logger.Error("Failed to remove temporary PDF file %s: %v", document.Path, err)
+		}
+	}
+}
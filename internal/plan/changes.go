@@ -0,0 +1,198 @@
+package plan
+
+import (
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// Change types reported in ScheduleChange.Type.
+const (
+	ChangeAdded       = "added"       // The match is new in the schedule
+	ChangeRemoved     = "removed"     // The match is no longer in the schedule
+	ChangeRescheduled = "rescheduled" // The match moved to another date, time or round
+	ChangeRelocated   = "relocated"   // The match moved to another venue
+	ChangeDecided     = "decided"     // The teams of a placeholder fixture are known now
+)
+
+// ScheduleChange lists the differences of one match between the planned and the current schedule.
+type ScheduleChange struct {
+	Type         string          `json:"type"`                   // The first of Kinds
+	Kinds        []string        `json:"kinds"`                  // Every Change* type that applies to the match, e.g. rescheduled and relocated
+	MatchID      string          `json:"matchId"`                // Current match ID (planned ID for removed matches)
+	Round        int             `json:"round"`                  // Round in the current schedule (planned round for removed matches)
+	PlannedRound int             `json:"plannedRound,omitempty"` // Planned round when the match moved to another round
+	HomeTeam     string          `json:"homeTeam"`               // Home team
+	GuestTeam    string          `json:"guestTeam"`              // Guest team
+	Before       *data.MatchInfo `json:"before,omitempty"`       // Match as planned, nil for added matches
+	After        *data.MatchInfo `json:"after,omitempty"`        // Match as currently scheduled, nil for removed matches
+	Delegations  []Delegation    `json:"delegations"`            // Issued delegations affected by the change
+}
+
+// ChangeReport lists all schedule changes and how many delegations they affect.
+type ChangeReport struct {
+	Changes             []ScheduleChange `json:"changes"`             // Changes in schedule order
+	AffectedDelegations int              `json:"affectedDelegations"` // Number of issued delegations that need to be reissued or withdrawn
+}
+
+// scheduledMatch is a match together with the round it is scheduled in.
+type scheduledMatch struct {
+	Round int
+	Match data.MatchInfo
+}
+
+// flatten lists the matches of all rounds in schedule order.
+func flatten(rounds []data.Round) []scheduledMatch {
+	var matches []scheduledMatch
+	for _, round := range rounds {
+		for _, match := range round.Matches {
+			matches = append(matches, scheduledMatch{Round: round.Number, Match: match})
+		}
+	}
+	return matches
+}
+
+// CompareRounds compares the planned schedule with the current one.
+// Placeholder fixtures are followed by their match ID, since their teams change once the bracket is decided.
+// Other matches are paired by round and teams first; a pairing that disappears from one round and appears in another
+// is reported as rescheduled rather than removed and added.
+func (p *Plan) CompareRounds(current []data.Round) ChangeReport {
+	planned := flatten(p.Rounds)
+	actual := flatten(current)
+
+	plannedUsed := make([]bool, len(planned))
+	actualUsed := make([]bool, len(actual))
+	pairs := make(map[int]int) // actual index -> planned index
+
+	// Placeholder fixtures keep their match ID when the teams become known
+	actualByID := make(map[string]int)
+	for j, m := range actual {
+		if m.Match.ID != "" {
+			actualByID[m.Match.ID] = j
+		}
+	}
+	for i, m := range planned {
+		if !m.Match.Placeholder || m.Match.ID == "" {
+			continue
+		}
+		if j, ok := actualByID[m.Match.ID]; ok && !actualUsed[j] {
+			pairs[j] = i
+			plannedUsed[i] = true
+			actualUsed[j] = true
+		}
+	}
+
+	// Same round and teams
+	plannedByKey := make(map[matchKey][]int)
+	for i, m := range planned {
+		if plannedUsed[i] {
+			continue
+		}
+		key := keyOf(m.Round, m.Match.HomeTeam, m.Match.GuestTeam)
+		plannedByKey[key] = append(plannedByKey[key], i)
+	}
+	for j, m := range actual {
+		if actualUsed[j] {
+			continue
+		}
+		key := keyOf(m.Round, m.Match.HomeTeam, m.Match.GuestTeam)
+		if candidates := plannedByKey[key]; len(candidates) > 0 {
+			pairs[j] = candidates[0]
+			plannedByKey[key] = candidates[1:]
+			plannedUsed[candidates[0]] = true
+			actualUsed[j] = true
+		}
+	}
+
+	// Same teams in another round
+	plannedByPairing := make(map[pairingKey][]int)
+	for i, m := range planned {
+		if !plannedUsed[i] {
+			key := pairingKey{data.NormalizeTeamName(m.Match.HomeTeam), data.NormalizeTeamName(m.Match.GuestTeam)}
+			plannedByPairing[key] = append(plannedByPairing[key], i)
+		}
+	}
+	for j, m := range actual {
+		if actualUsed[j] {
+			continue
+		}
+		key := pairingKey{data.NormalizeTeamName(m.Match.HomeTeam), data.NormalizeTeamName(m.Match.GuestTeam)}
+		if candidates := plannedByPairing[key]; len(candidates) > 0 {
+			pairs[j] = candidates[0]
+			plannedByPairing[key] = candidates[1:]
+			plannedUsed[candidates[0]] = true
+			actualUsed[j] = true
+		}
+	}
+
+	var report ChangeReport
+	add := func(change ScheduleChange, before scheduledMatch, hasBefore bool) {
+		if change.Kinds == nil {
+			change.Kinds = []string{change.Type}
+		}
+		if hasBefore {
+			change.Delegations = p.delegationsFor(before.Round, before.Match)
+		}
+		if change.Delegations == nil {
+			change.Delegations = []Delegation{}
+		}
+		report.AffectedDelegations += len(change.Delegations)
+		report.Changes = append(report.Changes, change)
+	}
+
+	for j, m := range actual {
+		after := m.Match
+		i, paired := pairs[j]
+		if !paired {
+			add(ScheduleChange{Type: ChangeAdded, MatchID: after.ID, Round: m.Round, HomeTeam: after.HomeTeam, GuestTeam: after.GuestTeam, After: &after}, scheduledMatch{}, false)
+			continue
+		}
+
+		before := planned[i].Match
+		change := ScheduleChange{MatchID: after.ID, Round: m.Round, HomeTeam: after.HomeTeam, GuestTeam: after.GuestTeam, Before: &before, After: &after}
+		if planned[i].Round != m.Round {
+			change.PlannedRound = planned[i].Round
+		}
+
+		// One change per match, so its delegations are counted once however much of it changed
+		if planned[i].Round != m.Round || !sameTime(before, after) {
+			change.Kinds = append(change.Kinds, ChangeRescheduled)
+		}
+		if !sameText(before.Address, after.Address) {
+			change.Kinds = append(change.Kinds, ChangeRelocated)
+		}
+		if before.Placeholder && !after.Placeholder {
+			change.Kinds = append(change.Kinds, ChangeDecided)
+		}
+		if len(change.Kinds) > 0 {
+			change.Type = change.Kinds[0]
+			add(change, planned[i], true)
+		}
+	}
+
+	for i, m := range planned {
+		if plannedUsed[i] {
+			continue
+		}
+		before := m.Match
+		add(ScheduleChange{Type: ChangeRemoved, MatchID: before.ID, Round: m.Round, HomeTeam: before.HomeTeam, GuestTeam: before.GuestTeam, Before: &before}, m, true)
+	}
+
+	if report.Changes == nil {
+		report.Changes = []ScheduleChange{}
+	}
+	return report
+}
+
+// sameTime compares the scheduled times, using the parsed times when both are available.
+func sameTime(a, b data.MatchInfo) bool {
+	if a.StartsAt != nil && b.StartsAt != nil {
+		return a.StartsAt.Equal(*b.StartsAt)
+	}
+	return sameText(a.DateTime, b.DateTime)
+}
+
+// sameText compares two values ignoring case and whitespace differences.
+func sameText(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
@@ -0,0 +1,178 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// changeSummary is the part of a schedule change the tests compare.
+type changeSummary struct {
+	Type         string
+	Kinds        []string
+	MatchID      string
+	Round        int
+	PlannedRound int
+	Delegations  int
+}
+
+// summarize reduces the changes of a report to comparable summaries.
+func summarize(report ChangeReport) []changeSummary {
+	summaries := []changeSummary{}
+	for _, change := range report.Changes {
+		summaries = append(summaries, changeSummary{
+			Type:         change.Type,
+			Kinds:        change.Kinds,
+			MatchID:      change.MatchID,
+			Round:        change.Round,
+			PlannedRound: change.PlannedRound,
+			Delegations:  len(change.Delegations),
+		})
+	}
+	return summaries
+}
+
+// round builds a schedule round with the given matches.
+func round(number int, matches ...data.MatchInfo) data.Round {
+	return data.Round{Number: number, Matches: matches}
+}
+
+// match builds a scheduled match.
+func match(id, home, guest, dateTime, address string) data.MatchInfo {
+	return data.MatchInfo{ID: id, HomeTeam: home, GuestTeam: guest, DateTime: dateTime, Address: address}
+}
+
+func TestCompareRounds(t *testing.T) {
+	planned := []data.Round{
+		round(1,
+			match("t-1-1", "ŠK Prievidza", "ŠKŠ Dubnica", "2025/10/25 11:00", "Hall A"),
+			match("t-1-2", "TJ Slávia", "ŠK Modra", "2025/10/25 11:00", "Hall B"),
+		),
+		round(2,
+			match("t-2-1", "ŠKŠ Dubnica", "TJ Slávia", "2025/11/08 11:00", "Hall C"),
+		),
+	}
+	delegations := []Delegation{
+		{MatchID: "t-1-1", Round: 1, HomeTeam: "ŠK Prievidza", GuestTeam: "ŠKŠ Dubnica", Arbiter: data.ArbiterData{LastName: "Novák"}},
+		{MatchID: "t-2-1", Round: 2, HomeTeam: "ŠKŠ Dubnica", GuestTeam: "TJ Slávia", Arbiter: data.ArbiterData{LastName: "Kováč"}},
+		{MatchID: "t-2-1", Round: 2, HomeTeam: "ŠKŠ Dubnica", GuestTeam: "TJ Slávia", Arbiter: data.ArbiterData{LastName: "Horváth"}},
+	}
+
+	tests := []struct {
+		name         string
+		planned      []data.Round
+		current      []data.Round
+		want         []changeSummary
+		wantAffected int
+	}{
+		{
+			name:    "unchanged schedule",
+			planned: planned,
+			current: planned,
+			want:    []changeSummary{},
+		},
+		{
+			name:    "team names compared ignoring case and spacing",
+			planned: planned,
+			current: []data.Round{
+				round(1,
+					match("t-1-1", "šk  prievidza", "ŠKŠ DUBNICA", "2025/10/25 11:00", "hall a"),
+					match("t-1-2", "TJ Slávia", "ŠK Modra", "2025/10/25  11:00", "Hall B"),
+				),
+				planned[1],
+			},
+			want: []changeSummary{},
+		},
+		{
+			name:    "new time",
+			planned: planned,
+			current: []data.Round{
+				round(1,
+					match("t-1-1", "ŠK Prievidza", "ŠKŠ Dubnica", "2025/10/26 10:00", "Hall A"),
+					planned[0].Matches[1],
+				),
+				planned[1],
+			},
+			want: []changeSummary{
+				{Type: ChangeRescheduled, Kinds: []string{ChangeRescheduled}, MatchID: "t-1-1", Round: 1, Delegations: 1},
+			},
+			wantAffected: 1,
+		},
+		{
+			name:    "new time and venue",
+			planned: planned,
+			current: []data.Round{
+				round(1,
+					match("t-1-1", "ŠK Prievidza", "ŠKŠ Dubnica", "2025/10/26 10:00", "Gym"),
+					planned[0].Matches[1],
+				),
+				planned[1],
+			},
+			want: []changeSummary{
+				{Type: ChangeRescheduled, Kinds: []string{ChangeRescheduled, ChangeRelocated}, MatchID: "t-1-1", Round: 1, Delegations: 1},
+			},
+			wantAffected: 1,
+		},
+		{
+			name:    "moved to another round",
+			planned: planned,
+			current: []data.Round{
+				planned[0],
+				round(3,
+					match("t-3-1", "ŠKŠ Dubnica", "TJ Slávia", "2025/11/08 11:00", "Hall C"),
+				),
+			},
+			want: []changeSummary{
+				{Type: ChangeRescheduled, Kinds: []string{ChangeRescheduled}, MatchID: "t-3-1", Round: 3, PlannedRound: 2, Delegations: 2},
+			},
+			wantAffected: 2,
+		},
+		{
+			name:    "removed and added",
+			planned: planned,
+			current: []data.Round{
+				round(1,
+					planned[0].Matches[1],
+					match("t-1-3", "ŠK Modra", "ŠK Prievidza", "2025/10/25 11:00", "Hall D"),
+				),
+				planned[1],
+			},
+			want: []changeSummary{
+				{Type: ChangeAdded, Kinds: []string{ChangeAdded}, MatchID: "t-1-3", Round: 1},
+				{Type: ChangeRemoved, Kinds: []string{ChangeRemoved}, MatchID: "t-1-1", Round: 1, Delegations: 1},
+			},
+			wantAffected: 1,
+		},
+		{
+			name: "placeholder fixture decided",
+			planned: []data.Round{
+				round(2, data.MatchInfo{ID: "t-2-1", HomeTeam: "Víťaz QF1", GuestTeam: "Víťaz QF2",
+					DateTime: "2025/11/08 11:00", Address: "Hall C", Placeholder: true}),
+			},
+			current: []data.Round{
+				round(2,
+					match("t-2-1", "ŠKŠ Dubnica", "TJ Slávia", "2025/11/08 11:00", "Hall C"),
+				),
+			},
+			want: []changeSummary{
+				{Type: ChangeDecided, Kinds: []string{ChangeDecided}, MatchID: "t-2-1", Round: 2, Delegations: 2},
+			},
+			wantAffected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Plan{LeagueID: "1", Rounds: tt.planned, Delegations: delegations}
+			report := p.CompareRounds(tt.current)
+
+			if got := summarize(report); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changes = %+v, want %+v", got, tt.want)
+			}
+			if report.AffectedDelegations != tt.wantAffected {
+				t.Errorf("affected delegations = %d, want %d", report.AffectedDelegations, tt.wantAffected)
+			}
+		})
+	}
+}
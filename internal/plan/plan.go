@@ -0,0 +1,135 @@
+// Package plan stores the delegation plan of each league and detects schedule changes against it.
+// A plan remembers the rounds the delegations were issued for and which arbiter was delegated to which match,
+// so a later chess-results schedule can be compared with it.
+package plan
+
+import (
+	"fmt"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+)
+
+// Plan is the stored delegation plan of one league.
+type Plan struct {
+	LeagueID    string       `json:"leagueId"`    // chess.sk league ID
+	LeagueName  string       `json:"leagueName"`  // League name at the time the plan was saved
+	Rounds      []data.Round `json:"rounds"`      // Schedule the delegations were issued for
+	Delegations []Delegation `json:"delegations"` // Issued delegations
+	SavedAt     time.Time    `json:"savedAt"`     // When the plan was last saved
+}
+
+// Delegation records one arbiter delegated to one match.
+type Delegation struct {
+	MatchID        string           `json:"matchId"`        // Stable match identifier, empty for matches entered by hand
+	Round          int              `json:"round"`          // Round number (0 when unknown)
+	HomeTeam       string           `json:"homeTeam"`       // Home team
+	GuestTeam      string           `json:"guestTeam"`      // Guest team
+	DateTime       string           `json:"dateTime"`       // Date and time printed on the delegation
+	Address        string           `json:"address"`        // Venue printed on the delegation
+	Arbiter        data.ArbiterData `json:"arbiter"`        // Delegated arbiter
+	Role           string           `json:"role,omitempty"` // Arbiter's role when the match has several arbiters
+	DocumentNumber string           `json:"documentNumber"` // Delegation number
+	IssuedAt       time.Time        `json:"issuedAt"`       // When the delegation was generated
+}
+
+// DelegationFromPDFData records a generated delegation.
+func DelegationFromPDFData(pdfData data.PDFData, issuedAt time.Time) Delegation {
+	return Delegation{
+		MatchID:        pdfData.Match.MatchID,
+		Round:          pdfData.Match.Round,
+		HomeTeam:       pdfData.Match.HomeTeam,
+		GuestTeam:      pdfData.Match.GuestTeam,
+		DateTime:       pdfData.Match.DateTime,
+		Address:        pdfData.Match.Address,
+		Arbiter:        pdfData.Arbiter,
+		Role:           pdfData.Role,
+		DocumentNumber: pdfData.DocumentNumber,
+		IssuedAt:       issuedAt,
+	}
+}
+
+// DelegationsFromPDFData records a generated match assignment,
+// one delegation per delegated arbiter and per match of a venue group.
+// letterPerOfficial tells whether every arbiter got a letter of their own, numbered as in pdf.ExpandOfficials.
+func DelegationsFromPDFData(pdfData data.PDFData, issuedAt time.Time, letterPerOfficial bool) []Delegation {
+	var delegations []Delegation
+	for _, match := range pdfData.CoveredMatches() {
+		for i, official := range pdfData.MatchOfficials() {
+			letter := pdfData
+			letter.Match = match
+			letter.Arbiter = official.Arbiter
+			letter.Role = official.Role
+			if letterPerOfficial {
+				letter.DocumentNumber = pdfData.OfficialDocumentNumber(i)
+			}
+			delegations = append(delegations, DelegationFromPDFData(letter, issuedAt))
+		}
+	}
+	return delegations
+}
+
+// matchKey identifies a match by round and teams.
+type matchKey struct {
+	Round     int
+	HomeTeam  string
+	GuestTeam string
+}
+
+// pairingKey identifies a match by its teams only, used to follow matches moved to another round.
+type pairingKey struct {
+	HomeTeam  string
+	GuestTeam string
+}
+
+// keyOf returns the match key of a match in the given round.
+func keyOf(round int, homeTeam, guestTeam string) matchKey {
+	return matchKey{Round: round, HomeTeam: data.NormalizeTeamName(homeTeam), GuestTeam: data.NormalizeTeamName(guestTeam)}
+}
+
+// delegationKey identifies the match a delegation belongs to: its match ID,
+// or round and teams for delegations of matches entered by hand.
+func delegationKey(matchID string, round int, homeTeam, guestTeam string) string {
+	if matchID != "" {
+		return matchID
+	}
+	key := keyOf(round, homeTeam, guestTeam)
+	return fmt.Sprintf("%d|%s|%s", key.Round, key.HomeTeam, key.GuestTeam)
+}
+
+// key returns the delegation's match key, see delegationKey.
+func (d Delegation) key() string {
+	return delegationKey(d.MatchID, d.Round, d.HomeTeam, d.GuestTeam)
+}
+
+// Merge adds newly issued delegations to the plan.
+// A delegation for a match that already has one replaces it; delegations for other matches are kept.
+func (p *Plan) Merge(delegations []Delegation) {
+	replaced := make(map[string]bool)
+	for _, delegation := range delegations {
+		replaced[delegation.key()] = true
+	}
+
+	var kept []Delegation
+	for _, delegation := range p.Delegations {
+		if !replaced[delegation.key()] {
+			kept = append(kept, delegation)
+		}
+	}
+	p.Delegations = append(kept, delegations...)
+}
+
+// delegationsFor returns the delegations issued for a planned match,
+// matched by match ID or, for delegations without one, by round and teams.
+func (p *Plan) delegationsFor(round int, match data.MatchInfo) []Delegation {
+	byTeams := delegationKey("", round, match.HomeTeam, match.GuestTeam)
+
+	var result []Delegation
+	for _, delegation := range p.Delegations {
+		if (delegation.MatchID != "" && delegation.MatchID == match.ID) ||
+			(delegation.MatchID == "" && delegation.key() == byTeams) {
+			result = append(result, delegation)
+		}
+	}
+	return result
+}
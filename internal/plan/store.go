@@ -0,0 +1,94 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/filestore"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// DefaultDir is where plans are stored, one JSON file per league.
+const DefaultDir = "assets/plans/"
+
+// Store persists plans as JSON files.
+type Store struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewStore creates a store keeping its files in dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// path returns the file of a league's plan.
+func (s *Store) path(leagueID string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("league_%s.json", leagueID))
+}
+
+// Load returns the stored plan of a league, or nil when none has been saved yet.
+func (s *Store) Load(leagueID string) (*Plan, error) {
+	if err := filestore.CheckLeagueID(leagueID); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(leagueID)
+}
+
+// load reads a plan without locking.
+func (s *Store) load(leagueID string) (*Plan, error) {
+	content, err := os.ReadFile(s.path(leagueID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan for league %s: %v", leagueID, err)
+	}
+
+	var plan Plan
+	if err := json.Unmarshal(content, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan for league %s: %v", leagueID, err)
+	}
+	return &plan, nil
+}
+
+// Update loads a league's plan (or starts an empty one), lets change modify it and saves it.
+func (s *Store) Update(leagueID string, change func(plan *Plan)) (*Plan, error) {
+	if err := filestore.CheckLeagueID(leagueID); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, err := s.load(leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		plan = &Plan{LeagueID: leagueID}
+	}
+
+	change(plan)
+	plan.SavedAt = time.Now()
+
+	if err := s.save(plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// save writes the plan atomically, so a crash never leaves a truncated plan.
+func (s *Store) save(plan *Plan) error {
+	if err := filestore.WriteJSONAtomic(s.path(plan.LeagueID), plan); err != nil {
+		return fmt.Errorf("failed to store plan: %v", err)
+	}
+
+	logger.Debug("Saved plan for league %s (%d rounds, %d delegations)", plan.LeagueID, len(plan.Rounds), len(plan.Delegations))
+	return nil
+}
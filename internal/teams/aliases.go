@@ -0,0 +1,126 @@
+package teams
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/filestore"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// DefaultAliasPath is where the confirmed team aliases are stored.
+const DefaultAliasPath = "assets/team_aliases.json"
+
+// Alias is a confirmed pairing of a chess-results team name with a chess.sk club.
+// It applies to every squad of the team ("ŠKŠ Dubnica B" also covers "ŠKŠ Dubnica A").
+type Alias struct {
+	Team        string    `json:"team"`        // Team name as confirmed
+	ClubID      string    `json:"clubId"`      // chess.sk club ID
+	ClubName    string    `json:"clubName"`    // chess.sk club name
+	ConfirmedAt time.Time `json:"confirmedAt"` // When the pairing was confirmed
+}
+
+// AliasStore persists confirmed aliases as a JSON file.
+// The file is read once and kept in memory; every write replaces the kept copy.
+type AliasStore struct {
+	mu      sync.Mutex
+	path    string
+	aliases []Alias // Aliases as last read or written, nil until the file has been read
+}
+
+// NewAliasStore creates a store keeping the aliases in the file at path.
+func NewAliasStore(path string) *AliasStore {
+	return &AliasStore{path: path}
+}
+
+// Load returns all confirmed aliases.
+func (s *AliasStore) Load() ([]Alias, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Confirm stores an alias, replacing an earlier one for the same team (squads included).
+func (s *AliasStore) Confirm(alias Alias) (*Alias, error) {
+	alias.Team = strings.TrimSpace(alias.Team)
+	if Key(alias.Team) == "" || alias.ClubID == "" {
+		return nil, fmt.Errorf("team and club ID are required")
+	}
+	alias.ConfirmedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aliases, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	aliases = withoutTeam(aliases, alias.Team)
+	aliases = append(aliases, alias)
+	if err := s.save(aliases); err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// Remove deletes the alias of a team. Returns false when the team had none.
+func (s *AliasStore) Remove(team string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aliases, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	remaining := withoutTeam(aliases, team)
+	if len(remaining) == len(aliases) {
+		return false, nil
+	}
+	return true, s.save(remaining)
+}
+
+// withoutTeam returns the aliases that do not belong to the team.
+func withoutTeam(aliases []Alias, team string) []Alias {
+	key := Key(team)
+	result := make([]Alias, 0, len(aliases))
+	for _, alias := range aliases {
+		if Key(alias.Team) != key {
+			result = append(result, alias)
+		}
+	}
+	return result
+}
+
+// load returns a copy of the aliases without locking, reading the file on first use.
+func (s *AliasStore) load() ([]Alias, error) {
+	if s.aliases == nil {
+		content, err := os.ReadFile(s.path)
+		if os.IsNotExist(err) {
+			content = []byte("[]")
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read team aliases: %v", err)
+		}
+
+		var aliases []Alias
+		if err := json.Unmarshal(content, &aliases); err != nil {
+			return nil, fmt.Errorf("failed to parse team aliases: %v", err)
+		}
+		s.aliases = append([]Alias{}, aliases...)
+	}
+	return append([]Alias{}, s.aliases...), nil
+}
+
+// save writes the aliases atomically, so a crash never leaves a truncated file.
+func (s *AliasStore) save(aliases []Alias) error {
+	if err := filestore.WriteJSONAtomic(s.path, aliases); err != nil {
+		return fmt.Errorf("failed to store team aliases: %v", err)
+	}
+	s.aliases = aliases
+
+	logger.Debug("Saved %d team aliases", len(aliases))
+	return nil
+}
@@ -0,0 +1,120 @@
+// Package teams resolves chess-results team names to chess.sk clubs.
+// Team names rarely match the club records ("ŠKŠ Dubnica B" vs. "ŠK Dubnica nad Váhom"), so names are
+// compared without diacritics and squad letters, confirmed pairs are kept in an alias table
+// and everything else gets fuzzy suggestions.
+package teams
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// squadSuffix matches a trailing squad designation: "B", "\"B\"", "-C", "II".
+var squadSuffix = regexp.MustCompile(`(?i)[\s\-]+["'„“]?([a-h]|i{1,3}|iv)["'“”]?$`)
+
+// legalForms are club name tokens that say nothing about the club itself.
+var legalForms = map[string]bool{
+	"sk": true, "sks": true, "ssk": true, "msk": true, "ks": true, "tj": true, "so": true,
+	"sach": true, "sachovy": true, "klub": true, "oz": true, "o.z.": true, "z.s.": true,
+}
+
+// specialFolds covers letters that do not decompose into an ASCII base letter.
+var specialFolds = map[rune]string{'ß': "ss", 'ø': "o", 'ł': "l", 'đ': "d", 'æ': "ae", 'œ': "oe"}
+
+// fold lowercases the name and removes diacritics ("ŠKŠ Dubnica" -> "sks dubnica").
+func fold(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(name)) {
+		if replacement, ok := specialFolds[r]; ok {
+			b.WriteString(replacement)
+			continue
+		}
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// StripSquad removes a trailing squad letter or number from a team name ("ŠKŠ Dubnica B" -> "ŠKŠ Dubnica").
+func StripSquad(team string) string {
+	team = strings.TrimSpace(team)
+	stripped := strings.TrimSpace(squadSuffix.ReplaceAllString(team, ""))
+	if stripped == "" {
+		return team
+	}
+	return stripped
+}
+
+// Key returns the comparison key of a team or club name: folded and without the squad designation.
+func Key(name string) string {
+	return fold(StripSquad(name))
+}
+
+// tokens splits a key into the words that identify the club, leaving out legal forms and punctuation.
+func tokens(key string) []string {
+	var result []string
+	for _, field := range strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+	}) {
+		field = strings.Trim(field, ".")
+		if field == "" || legalForms[field] {
+			continue
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+// Similarity scores how likely two names are the same club, from 0 (unrelated) to 1 (same key).
+// It is the Dice coefficient of the identifying words, where a word also matches its abbreviation
+// ("n." matches "nad", "dubnica" matches "dubnici" by a common prefix of five letters).
+func Similarity(a, b string) float64 {
+	keyA, keyB := Key(a), Key(b)
+	if keyA == "" || keyB == "" {
+		return 0
+	}
+	if keyA == keyB {
+		return 1
+	}
+
+	tokensA, tokensB := tokens(keyA), tokens(keyB)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	used := make([]bool, len(tokensB))
+	matched := 0
+	for _, tokenA := range tokensA {
+		for j, tokenB := range tokensB {
+			if !used[j] && sameWord(tokenA, tokenB) {
+				used[j] = true
+				matched++
+				break
+			}
+		}
+	}
+	return 2 * float64(matched) / float64(len(tokensA)+len(tokensB))
+}
+
+// sameWord reports whether two words are equal, one abbreviates the other, or they share a long prefix.
+func sameWord(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(a) <= 3 {
+		return strings.HasPrefix(b, a)
+	}
+	prefix := 5
+	if len(a) < prefix {
+		prefix = len(a)
+	}
+	return a[:prefix] == b[:prefix]
+}
@@ -0,0 +1,35 @@
+package teams
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"same key ignoring diacritics and squad", "ŠKŠ Dubnica B", "SKS Dubnica", 1},
+		{"abbreviated word", "ŠK Dubnica n. Váhom", "ŠK Dubnica nad Váhom", 1},
+		{"common prefix", "ŠK Dubnici", "ŠK Dubnica", 1},
+		{"legal forms are ignored", "ŠKŠ Dubnica B", "ŠK Dubnica nad Váhom", 0.5},
+		{"one of two words", "Slávia Trnava", "Slavia Bratislava", 0.5},
+		{"one of three words", "ŠK Modra Harmónia", "Modra", 2.0 / 3},
+		{"unrelated clubs", "TJ Slávia Košice", "ŠK Modra", 0},
+		{"only legal forms", "ŠK", "TJ", 0},
+		{"empty name", "", "ŠK Modra", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Similarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := Similarity(tt.b, tt.a); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Similarity(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
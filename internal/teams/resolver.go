@@ -0,0 +1,110 @@
+package teams
+
+import (
+	"sort"
+)
+
+// Resolution sources reported in Resolution.Source.
+const (
+	SourceAlias      = "alias"      // A confirmed alias
+	SourceExact      = "exact"      // The club name equals the team name without diacritics and squad letter
+	SourceSuggested  = "suggested"  // Only fuzzy suggestions, waiting for confirmation
+	SourceUnresolved = "unresolved" // No club looks similar
+)
+
+// SuggestionThreshold is the lowest similarity offered as a suggestion.
+const SuggestionThreshold = 0.5
+
+// maxSuggestions limits the suggestions returned for one team.
+const maxSuggestions = 3
+
+// Club is a chess.sk club a team can be resolved to.
+type Club struct {
+	ID   string `json:"id"`   // chess.sk club ID
+	Name string `json:"name"` // Club name
+}
+
+// Candidate is a suggested club with its similarity score.
+type Candidate struct {
+	Club
+	Score float64 `json:"score"` // Similarity from 0 to 1
+}
+
+// Resolution is the club a team name resolves to.
+// ClubID and ClubName are only set for confirmed aliases and exact matches; suggestions must be confirmed first.
+type Resolution struct {
+	Team        string      `json:"team"`                  // Team name as used in the schedule
+	ClubID      string      `json:"clubId,omitempty"`      // Canonical club ID
+	ClubName    string      `json:"clubName,omitempty"`    // Canonical club name
+	Source      string      `json:"source"`                // One of the Source* values
+	Suggestions []Candidate `json:"suggestions,omitempty"` // Best matching clubs, most similar first
+}
+
+// Resolver resolves team names with the confirmed aliases and the known clubs.
+type Resolver struct {
+	aliases map[string]Alias
+	clubs   []Club
+}
+
+// NewResolver creates a resolver for the given aliases and clubs.
+func NewResolver(aliases []Alias, clubs []Club) *Resolver {
+	byKey := make(map[string]Alias, len(aliases))
+	for _, alias := range aliases {
+		byKey[Key(alias.Team)] = alias
+	}
+	return &Resolver{aliases: byKey, clubs: clubs}
+}
+
+// Resolve returns the club of a team: a confirmed alias first, then an exact club name, then suggestions.
+func (r *Resolver) Resolve(team string) Resolution {
+	resolution := Resolution{Team: team, Source: SourceUnresolved}
+	key := Key(team)
+
+	if alias, ok := r.aliases[key]; ok {
+		resolution.ClubID, resolution.ClubName, resolution.Source = alias.ClubID, alias.ClubName, SourceAlias
+		return resolution
+	}
+
+	var candidates []Candidate
+	for _, club := range r.clubs {
+		if Key(club.Name) == key {
+			resolution.ClubID, resolution.ClubName, resolution.Source = club.ID, club.Name, SourceExact
+			return resolution
+		}
+		if score := Similarity(team, club.Name); score >= SuggestionThreshold {
+			candidates = append(candidates, Candidate{Club: club, Score: score})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if len(candidates) > maxSuggestions {
+		candidates = candidates[:maxSuggestions]
+	}
+	if len(candidates) > 0 {
+		resolution.Source = SourceSuggested
+		resolution.Suggestions = candidates
+	}
+	return resolution
+}
+
+// ClubID returns the canonical club ID of a team, or "" when it is not resolved.
+func (r *Resolver) ClubID(team string) string {
+	return r.Resolve(team).ClubID
+}
+
+// ClubName returns the canonical club name of a team, or "" when it is not resolved.
+func (r *Resolver) ClubName(team string) string {
+	return r.Resolve(team).ClubName
+}
+
+// Club returns the known club with the given ID.
+func (r *Resolver) Club(id string) (Club, bool) {
+	for _, club := range r.clubs {
+		if club.ID == id {
+			return club, true
+		}
+	}
+	return Club{}, false
+}
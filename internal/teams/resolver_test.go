@@ -0,0 +1,75 @@
+package teams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolverResolve(t *testing.T) {
+	aliases := []Alias{
+		{Team: "ŠKŠ Dubnica A", ClubID: "100", ClubName: "ŠK Dubnica nad Váhom"},
+	}
+	clubs := []Club{
+		{ID: "100", Name: "ŠK Dubnica nad Váhom"},
+		{ID: "200", Name: "ŠK Modra"},
+		{ID: "300", Name: "TJ Slávia Trnava"},
+		{ID: "301", Name: "Slávia Bratislava"},
+		{ID: "302", Name: "ŠK Slávia Nitra Mesto"},
+		{ID: "303", Name: "Slávia Žilina"},
+	}
+	resolver := NewResolver(aliases, clubs)
+
+	tests := []struct {
+		name            string
+		team            string
+		wantSource      string
+		wantClubID      string
+		wantSuggestions []string
+	}{
+		{
+			name:       "alias covers every squad",
+			team:       "ŠKŠ Dubnica B",
+			wantSource: SourceAlias,
+			wantClubID: "100",
+		},
+		{
+			name:       "exact club name without squad letter",
+			team:       "ŠK Modra B",
+			wantSource: SourceExact,
+			wantClubID: "200",
+		},
+		{
+			name:            "suggestions are ordered and limited",
+			team:            "Slávia",
+			wantSource:      SourceSuggested,
+			wantSuggestions: []string{"300", "301", "303"},
+		},
+		{
+			name:       "no similar club",
+			team:       "Bohemians Praha",
+			wantSource: SourceUnresolved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolver.Resolve(tt.team)
+			if got.Team != tt.team {
+				t.Errorf("Team = %q, want %q", got.Team, tt.team)
+			}
+			if got.Source != tt.wantSource {
+				t.Errorf("Source = %q, want %q", got.Source, tt.wantSource)
+			}
+			if got.ClubID != tt.wantClubID {
+				t.Errorf("ClubID = %q, want %q", got.ClubID, tt.wantClubID)
+			}
+			var suggestions []string
+			for _, candidate := range got.Suggestions {
+				suggestions = append(suggestions, candidate.ID)
+			}
+			if !reflect.DeepEqual(suggestions, tt.wantSuggestions) {
+				t.Errorf("Suggestions = %v, want %v", suggestions, tt.wantSuggestions)
+			}
+		})
+	}
+}
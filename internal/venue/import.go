@@ -0,0 +1,99 @@
+package venue
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// importHeaderNames are the first-column headers that mark a header row in an import file.
+var importHeaderNames = []string{"team", "club", "klub", "družstvo", "tím"}
+
+// ImportResult summarises an XLSX import.
+type ImportResult struct {
+	Imported    int   `json:"imported"`    // Rows that added or changed a venue
+	SkippedRows []int `json:"skippedRows"` // 1-based rows without a team or address
+}
+
+// ImportFile reads venues from the first sheet of an XLSX file and saves them to the store.
+// Columns: team (or club), address and an optional season. Rows with a season set an override
+// for that season; rows without one set the venue's default address. A header row is skipped.
+func (s *Store) ImportFile(filePath string) (*ImportResult, error) {
+	f, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(f.GetSheetName(0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows from sheet: %v", err)
+	}
+
+	result := &ImportResult{SkippedRows: []int{}}
+	err = s.update(func(directory *Directory) error {
+		for i, row := range rows {
+			team, address, season := column(row, 0), column(row, 1), column(row, 2)
+			if i == 0 && isImportHeader(team) {
+				continue
+			}
+			if team == "" || address == "" {
+				if team != "" || address != "" || season != "" {
+					result.SkippedRows = append(result.SkippedRows, i+1)
+				}
+				continue
+			}
+
+			venue := Venue{Team: team}
+			if existing := directory.exact(team); existing != nil {
+				venue = *existing
+			}
+			if season == "" {
+				venue.Address = address
+			} else {
+				seasons := make(map[string]string, len(venue.Seasons)+1)
+				for key, value := range venue.Seasons {
+					seasons[key] = value
+				}
+				seasons[season] = address
+				venue.Seasons = seasons
+			}
+			directory.put(venue)
+			result.Imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// exact returns the venue registered under exactly this team name (case-insensitive), or nil.
+func (d *Directory) exact(team string) *Venue {
+	for i := range d.Venues {
+		if strings.EqualFold(d.Venues[i].Team, team) {
+			return &d.Venues[i]
+		}
+	}
+	return nil
+}
+
+// column returns the trimmed cell at index, or "" when the row is too short.
+func column(row []string, index int) string {
+	if index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+// isImportHeader reports whether the first cell of a row is a column header.
+func isImportHeader(value string) bool {
+	for _, name := range importHeaderNames {
+		if strings.EqualFold(value, name) {
+			return true
+		}
+	}
+	return false
+}
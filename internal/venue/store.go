@@ -0,0 +1,149 @@
+package venue
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/filestore"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/logger"
+)
+
+// DefaultPath is where the venue directory is stored.
+const DefaultPath = "assets/venues.json"
+
+// ErrNotFound is returned for operations on a venue ID that is not in the directory.
+var ErrNotFound = fmt.Errorf("venue not found")
+
+// Store persists the venue directory as a JSON file.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a store keeping the directory in the file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Load returns the stored directory, or an empty one when nothing has been saved yet.
+func (s *Store) Load() (*Directory, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Save adds the venue, or replaces the venue with the same ID when it has one.
+// A new venue for a team that is already in the directory replaces that entry.
+// Returns the saved venue with its ID.
+func (s *Store) Save(venue Venue) (*Venue, error) {
+	venue.Team = strings.TrimSpace(venue.Team)
+	venue.Address = strings.TrimSpace(venue.Address)
+	if venue.Team == "" {
+		return nil, fmt.Errorf("team is required")
+	}
+
+	var saved Venue
+	err := s.update(func(directory *Directory) error {
+		saved = directory.put(venue)
+		if saved.ID == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// Delete removes the venue with the given ID.
+func (s *Store) Delete(id int) error {
+	return s.update(func(directory *Directory) error {
+		index := directory.find(id)
+		if index < 0 {
+			return ErrNotFound
+		}
+		directory.Venues = append(directory.Venues[:index], directory.Venues[index+1:]...)
+		return nil
+	})
+}
+
+// put adds or replaces a venue and returns it with its ID; the ID is 0 when venue.ID does not exist.
+func (d *Directory) put(venue Venue) Venue {
+	index := -1
+	if venue.ID != 0 {
+		index = d.find(venue.ID)
+		if index < 0 {
+			return Venue{}
+		}
+	} else {
+		for i, existing := range d.Venues {
+			if strings.EqualFold(existing.Team, venue.Team) {
+				index = i
+				venue.ID = existing.ID
+				break
+			}
+		}
+	}
+
+	if index >= 0 {
+		d.Venues[index] = venue
+		return venue
+	}
+
+	if d.NextID == 0 {
+		d.NextID = 1
+	}
+	venue.ID = d.NextID
+	d.NextID++
+	d.Venues = append(d.Venues, venue)
+	return venue
+}
+
+// update loads the directory, lets change modify it and saves it unless change fails.
+func (s *Store) update(change func(directory *Directory) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	directory, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := change(directory); err != nil {
+		return err
+	}
+	return s.save(directory)
+}
+
+// load reads the directory without locking.
+func (s *Store) load() (*Directory, error) {
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &Directory{NextID: 1, Venues: []Venue{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read venue directory: %v", err)
+	}
+
+	var directory Directory
+	if err := json.Unmarshal(content, &directory); err != nil {
+		return nil, fmt.Errorf("failed to parse venue directory: %v", err)
+	}
+	if directory.Venues == nil {
+		directory.Venues = []Venue{}
+	}
+	return &directory, nil
+}
+
+// save writes the directory atomically, so a crash never leaves a truncated file.
+func (s *Store) save(directory *Directory) error {
+	if err := filestore.WriteJSONAtomic(s.path, directory); err != nil {
+		return fmt.Errorf("failed to store venue directory: %v", err)
+	}
+
+	logger.Debug("Saved venue directory (%d venues)", len(directory.Venues))
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package venue keeps the directory of playing halls used by home teams.
+// Schedules often leave the match address empty; the directory fills it from the home team's club.
+package venue
+
+import (
+	"strings"
+
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/data"
+	"eu.michalvalko.chess_arbiter_delegation_generator/internal/teams"
+)
+
+// Venue is the playing hall of a club or home team.
+type Venue struct {
+	ID      int               `json:"id"`                // Directory ID, assigned when the venue is added
+	Team    string            `json:"team"`              // Club or home team name, e.g. "ŠKŠ Dubnica" covers "ŠKŠ Dubnica B"
+	Address string            `json:"address"`           // Playing hall address
+	Seasons map[string]string `json:"seasons,omitempty"` // Address overrides by season name (e.g. "2025/2026")
+}
+
+// Directory is the persisted list of venues.
+type Directory struct {
+	NextID int     `json:"nextId"` // ID given to the next added venue
+	Venues []Venue `json:"venues"` // Venues in the order they were added
+}
+
+// AddressFor returns the venue's address in the given season.
+func (v Venue) AddressFor(season string) string {
+	if address := strings.TrimSpace(v.Seasons[season]); address != "" {
+		return address
+	}
+	return v.Address
+}
+
+// covers reports whether the venue belongs to the team, ignoring diacritics.
+// A club name covers all its squads ("ŠKŠ Dubnica" covers "ŠKŠ Dubnica B").
+func (v Venue) covers(team string) bool {
+	venueTeam := teams.Key(v.Team)
+	team = teams.Key(team)
+	return venueTeam != "" && (team == venueTeam || strings.HasPrefix(team, venueTeam+" "))
+}
+
+// Lookup returns the venue of a home team. An exact team name wins over a club name;
+// among club names the longest one wins. Returns nil when the directory has no venue for the team.
+func (d *Directory) Lookup(team string) *Venue {
+	var best *Venue
+	for i := range d.Venues {
+		venue := &d.Venues[i]
+		if !venue.covers(team) {
+			continue
+		}
+		if data.NormalizeTeamName(venue.Team) == data.NormalizeTeamName(team) {
+			return venue
+		}
+		if best == nil || len(venue.Team) > len(best.Team) {
+			best = venue
+		}
+	}
+	return best
+}
+
+// FillAddresses sets the address of every match without one from the home team's venue in the given season.
+// When the directory has no venue for the team name, the venue of the team's club is used;
+// clubName returns the resolved club of a team ("" when unknown) and may be nil.
+// Returns the number of matches that were filled in.
+func (d *Directory) FillAddresses(rounds []data.Round, season string, clubName func(team string) string) int {
+	filled := 0
+	for i := range rounds {
+		for j := range rounds[i].Matches {
+			match := &rounds[i].Matches[j]
+			if strings.TrimSpace(match.Address) != "" {
+				continue
+			}
+			venue := d.Lookup(match.HomeTeam)
+			if venue == nil && clubName != nil {
+				if club := clubName(match.HomeTeam); club != "" {
+					venue = d.Lookup(club)
+				}
+			}
+			if venue != nil && venue.AddressFor(season) != "" {
+				match.Address = venue.AddressFor(season)
+				filled++
+			}
+		}
+	}
+	return filled
+}
+
+// find returns the index of the venue with the given ID, or -1.
+func (d *Directory) find(id int) int {
+	for i, venue := range d.Venues {
+		if venue.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -45,6 +45,10 @@ async function loadExternalData() {
             status.innerHTML = `
                 <span class="text-green-600">✓ ${result.message}</span><br>
             `;
+            if (result.clubs_error) {
+                console.warn('[DATA-LOADING] ⚠ Clubs not loaded:', result.clubs_error);
+                status.innerHTML += `<span class="text-orange-600">⚠ Kluby sa nenačítali: ${result.clubs_error}</span>`;
+            }
             
             if (result.arbiters_loaded && result.leagues_loaded) {
                 console.log('[DATA-LOADING] Both arbiters and leagues loaded, populating dropdown');
@@ -132,6 +136,42 @@ async function populateLeagueDropdown() {
 }
 
 
+// Register a league that the chess.sk API does not return and select it
+async function addCustomLeague() {
+    const status = document.getElementById('customLeagueStatus');
+    const value = id => document.getElementById(id).value.trim();
+
+    try {
+        const response = await fetch('/custom-leagues', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({
+                leagueName: value('customLeagueName'),
+                saisonName: value('customLeagueSeason'),
+                directorFirstName: value('customLeagueDirectorFirstName'),
+                directorSurname: value('customLeagueDirectorSurname'),
+                directorEmail: value('customLeagueDirectorEmail'),
+                chessResults: value('customLeagueChessResults')
+            })
+        });
+        const result = await response.json();
+        if (!response.ok) {
+            throw new Error(result.error || `HTTP error! status: ${response.status}`);
+        }
+
+        status.innerHTML = `<span class="text-green-600">✓ Súťaž ${result.league.leagueName} bola pridaná</span>`;
+        await populateLeagueDropdown();
+        const leagueSelect = document.getElementById('leagueSelect');
+        leagueSelect.value = result.league.leagueId;
+        await onLeagueSelected();
+    } catch (error) {
+        console.error('[CUSTOM-LEAGUE] ✗ Error adding league:', error);
+        status.innerHTML = `<span class="text-red-600">✗ ${error.message}</span>`;
+    }
+}
+
 async function onLeagueSelected() {
     console.log('[LEAGUE-SELECTED] ===== START onLeagueSelected =====');
     const leagueSelect = document.getElementById('leagueSelect');
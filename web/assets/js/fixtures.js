@@ -0,0 +1,100 @@
+// Placeholder fixtures of cup competitions: matches whose teams are not known yet (e.g. "Víťaz QF1")
+
+// Render the form for adding a placeholder fixture to the current league
+function renderFixtureForm() {
+    return `
+        <details id="fixturesSection" class="mb-8 p-4 bg-purple-50 border border-purple-200 rounded-lg">
+            <summary class="text-lg font-medium text-purple-800 cursor-pointer">Zápasy pavúka, ktorých súperi ešte nie sú známi</summary>
+            <p class="text-sm text-purple-800 mt-2">Číslo kola a párovania musí zodpovedať chess-results, aby sa súperi doplnili automaticky po aktualizácii pavúka.</p>
+            <div class="grid grid-cols-2 md:grid-cols-6 gap-2 mt-3">
+                <input type="number" id="fixtureRound" min="1" placeholder="Kolo" class="px-2 py-1 text-sm border border-gray-300 rounded" />
+                <input type="number" id="fixturePairing" min="1" placeholder="Párovanie" class="px-2 py-1 text-sm border border-gray-300 rounded" />
+                <input type="text" id="fixtureHomeLabel" placeholder="Domáci (napr. Víťaz QF1)" class="px-2 py-1 text-sm border border-gray-300 rounded" />
+                <input type="text" id="fixtureGuestLabel" placeholder="Hostia (napr. Víťaz QF2)" class="px-2 py-1 text-sm border border-gray-300 rounded" />
+                <input type="text" id="fixtureDateTime" placeholder="RRRR/MM/DD HH:MM" class="px-2 py-1 text-sm border border-gray-300 rounded" />
+                <input type="text" id="fixtureAddress" placeholder="Adresa hracej miestnosti" class="px-2 py-1 text-sm border border-gray-300 rounded" />
+            </div>
+            <button
+                type="button"
+                onclick="saveFixture()"
+                class="mt-3 px-3 py-1 text-sm border border-purple-400 rounded hover:bg-purple-100"
+            >Pridať zápas</button>
+            <ul id="fixtureList" class="mt-3 text-sm text-purple-900 space-y-1"></ul>
+        </details>
+    `;
+}
+
+// List the stored placeholder fixtures of the current league
+async function loadFixtures() {
+    const list = document.getElementById('fixtureList');
+    if (!list || !currentLeague) {
+        return;
+    }
+    try {
+        const response = await fetch(`/fixtures/${currentLeague.leagueId}`);
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || `HTTP error! status: ${response.status}`);
+        }
+        list.innerHTML = (data.fixtures || []).map(fixture => `
+            <li>
+                ${fixture.round}. kolo, párovanie ${fixture.pairing}: ${escapeHtml(fixture.homeLabel)} – ${escapeHtml(fixture.guestLabel)}
+                <span class="text-gray-600">${escapeHtml(fixture.dateTime)} ${escapeHtml(fixture.address)}</span>
+                <button type="button"
+                    onclick="deleteFixture(${fixture.round}, ${fixture.pairing})"
+                    class="ml-2 text-xs text-red-600 hover:text-red-800 underline"
+                >Odstrániť</button>
+            </li>
+        `).join('');
+    } catch (error) {
+        console.error('[FIXTURES] ✗ Error loading fixtures:', error);
+    }
+}
+
+// Store the fixture from the form and reload the rounds, so it can be delegated right away
+async function saveFixture() {
+    if (!currentLeague) {
+        return;
+    }
+    const fixture = {
+        round: parseInt(document.getElementById('fixtureRound').value) || 0,
+        pairing: parseInt(document.getElementById('fixturePairing').value) || 0,
+        homeLabel: document.getElementById('fixtureHomeLabel').value,
+        guestLabel: document.getElementById('fixtureGuestLabel').value,
+        dateTime: document.getElementById('fixtureDateTime').value,
+        address: document.getElementById('fixtureAddress').value
+    };
+    try {
+        const response = await fetch(`/fixtures/${currentLeague.leagueId}`, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(fixture)
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || `HTTP error! status: ${response.status}`);
+        }
+        await loadRoundsData(parseInt(currentLeague.leagueId));
+        showStatus('Zápas pavúka bol pridaný', 'success');
+    } catch (error) {
+        showStatus('Chyba pri pridaní zápasu: ' + error.message, 'error');
+    }
+}
+
+// Remove a stored fixture and reload the rounds
+async function deleteFixture(round, pairing) {
+    if (!currentLeague) {
+        return;
+    }
+    try {
+        const response = await fetch(`/fixtures/${currentLeague.leagueId}/${round}/${pairing}`, { method: 'DELETE' });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || `HTTP error! status: ${response.status}`);
+        }
+        await loadRoundsData(parseInt(currentLeague.leagueId));
+        showStatus('Zápas pavúka bol odstránený', 'success');
+    } catch (error) {
+        showStatus('Chyba pri odstránení zápasu: ' + error.message, 'error');
+    }
+}
@@ -3,19 +3,33 @@ let currentRounds = [];
 let currentLeague = null;
 let directorInfo = '';
 let contactPerson = '';
+let currentWarnings = [];
+let currentChanges = null;
+let currentTournament = null;
+let playedRounds = 0;
+let venueGroups = [];
+// Arbiters chosen in the editor, keyed by match ID so they survive reloading the schedule
+const arbiterAssignments = {};
+
+// Arbiter roles for matches and tournaments with several arbiters, in the order they are listed on delegations
+const ARBITER_ROLES = [
+    { value: 'chief_arbiter', label: 'Hlavný rozhodca' },
+    { value: 'deputy_arbiter', label: 'Zástupca hlavného rozhodcu' },
+    { value: 'arbiter', label: 'Rozhodca' }
+];
 
 const EYE_OPEN_SVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" width="16" height="16"><path d="M1 12s4-8 11-8 11 8 11 8-4 8-11 8-11-8-11-8z"/><circle cx="12" cy="12" r="3"/></svg>`;
 const EYE_CLOSED_SVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" width="16" height="16"><path d="M17.94 17.94A10.07 10.07 0 0 1 12 20c-7 0-11-8-11-8a18.45 18.45 0 0 1 5.06-5.94"/><path d="M9.9 4.24A9.12 9.12 0 0 1 12 4c7 0 11 8 11 8a18.5 18.5 0 0 1-2.16 3.19"/><line x1="1" y1="1" x2="23" y2="23"/></svg>`;
 
 // Load rounds data for a specific league
-async function loadRoundsData(leagueId) {
+async function loadRoundsData(leagueId, refresh = false) {
     console.log('[ROUNDS-LOADING] ===== START loadRoundsData =====');
     console.log('[ROUNDS-LOADING] League ID:', leagueId);
     console.log('[ROUNDS-LOADING] League ID type:', typeof leagueId);
 
     try {
         console.log('[ROUNDS-LOADING] Preparing POST request to /get-rounds');
-        const requestBody = { leagueId: leagueId };
+        const requestBody = { leagueId: leagueId, refresh: refresh, includePlayed: includePlayedRounds() };
         console.log('[ROUNDS-LOADING] Request body:', JSON.stringify(requestBody));
 
         const requestStartTime = performance.now();
@@ -52,6 +66,8 @@ async function loadRoundsData(leagueId) {
             roundsCount: data.rounds ? data.rounds.length : 0,
             hasLeague: !!data.league,
             leagueName: data.league ? data.league.leagueName : null,
+            dialect: data.dialect,
+            warningsCount: data.warnings ? data.warnings.length : 0,
             message: data.message
         });
 
@@ -76,23 +92,8 @@ async function loadRoundsData(leagueId) {
             });
         }
 
-        currentRounds = data.rounds || [];
-        currentLeague = data.league;
-        console.log('[ROUNDS-LOADING] Updated currentRounds:', currentRounds.length, 'rounds');
-        console.log('[ROUNDS-LOADING] Updated currentLeague:', currentLeague);
-
-        // Extract director info and contact person from league
-        directorInfo = `${currentLeague.directorFirstName} ${currentLeague.directorSurname}`;
-        if (currentLeague.directorEmail) {
-            directorInfo += ` (${currentLeague.directorEmail})`;
-        }
-        contactPerson = '';
-        console.log('[ROUNDS-LOADING] Director info:', directorInfo);
-        console.log('[ROUNDS-LOADING] Contact person:', contactPerson);
-
-        console.log('[ROUNDS-LOADING] Calling displayRoundsEditor()');
-        displayRoundsEditor();
-        console.log('[ROUNDS-LOADING] ✓ displayRoundsEditor() completed');
+        applyRoundsData(data);
+        showFetchStatus(data);
 
         console.log('[ROUNDS-LOADING] ===== END loadRoundsData (success) =====');
         return data;
@@ -108,6 +109,283 @@ async function loadRoundsData(leagueId) {
     }
 }
 
+// Store a rounds response (from /get-rounds or /upload-rounds) and show the editor
+function applyRoundsData(data) {
+    currentRounds = data.rounds || [];
+    currentLeague = data.league;
+    currentWarnings = data.warnings || [];
+    currentChanges = data.changes || null;
+    currentTournament = data.tournament || null;
+    playedRounds = data.playedRounds || 0;
+    venueGroups = data.venueGroups || [];
+    console.log('[ROUNDS-LOADING] Updated currentRounds:', currentRounds.length, 'rounds');
+    console.log('[ROUNDS-LOADING] Updated currentLeague:', currentLeague);
+
+    // Extract director info and contact person from league
+    directorInfo = `${currentLeague.directorFirstName} ${currentLeague.directorSurname}`;
+    if (currentLeague.directorEmail) {
+        directorInfo += ` (${currentLeague.directorEmail})`;
+    }
+    contactPerson = '';
+    console.log('[ROUNDS-LOADING] Director info:', directorInfo);
+    console.log('[ROUNDS-LOADING] Contact person:', contactPerson);
+
+    console.log('[ROUNDS-LOADING] Calling displayRoundsEditor()');
+    displayRoundsEditor();
+    console.log('[ROUNDS-LOADING] ✓ displayRoundsEditor() completed');
+}
+
+// Show when the schedule was downloaded from chess-results and whether a cached copy was used
+function showFetchStatus(data) {
+    if (!data.fetchedAt) {
+        return;
+    }
+    const fetchedAt = new Date(data.fetchedAt).toLocaleString('sk-SK');
+    if (data.stale) {
+        showStatus(`chess-results je nedostupný, zobrazený rozpis z ${fetchedAt}`, 'error');
+    } else if (data.fromCache) {
+        showStatus(`Rozpis stiahnutý ${fetchedAt} (uložená kópia)`, 'info');
+    } else {
+        showStatus(`Rozpis stiahnutý ${fetchedAt}`, 'success');
+    }
+}
+
+// Download the selected league's schedule again, ignoring the cached copy
+async function refreshRoundsData() {
+    const leagueSelect = document.getElementById('leagueSelect');
+    if (!leagueSelect || !leagueSelect.value) {
+        showStatus('Najprv vyberte ligu', 'error');
+        return;
+    }
+    showStatus('Sťahujem rozpis z chess-results...', 'info');
+    try {
+        await loadRoundsData(parseInt(leagueSelect.value), true);
+    } catch (error) {
+        console.error('[ROUNDS-LOADING] ✗ Error refreshing rounds data:', error);
+    }
+}
+
+// Load rounds from a chess-results Excel file chosen by the user instead of downloading it
+async function uploadRoundsFile() {
+    const leagueSelect = document.getElementById('leagueSelect');
+    const fileInput = document.getElementById('roundsFile');
+    const file = fileInput && fileInput.files[0];
+
+    if (!leagueSelect || !leagueSelect.value) {
+        showStatus('Najprv vyberte ligu', 'error');
+        return;
+    }
+    if (!file) {
+        showStatus('Vyberte súbor .xlsx', 'error');
+        return;
+    }
+
+    const formData = new FormData();
+    formData.append('leagueId', leagueSelect.value);
+    formData.append('file', file);
+    formData.append('includePlayed', includePlayedRounds());
+
+    try {
+        showStatus(`Spracúvam ${file.name}...`, 'info');
+        const response = await fetch('/upload-rounds', {
+            method: 'POST',
+            body: formData
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || `HTTP error! status: ${response.status}`);
+        }
+
+        applyRoundsData(data);
+        showStatus(`Rozpis načítaný zo súboru ${file.name}`, 'success');
+    } catch (error) {
+        console.error('[ROUNDS-UPLOAD] ✗ Error uploading schedule:', error);
+        showStatus('Chyba pri načítaní súboru: ' + error.message, 'error');
+    } finally {
+        fileInput.value = '';
+    }
+}
+
+// Import the venue directory (team or club, address, optional season) from an .xlsx file
+async function importVenuesFile() {
+    const fileInput = document.getElementById('venuesFile');
+    const file = fileInput && fileInput.files[0];
+    if (!file) {
+        return;
+    }
+
+    const formData = new FormData();
+    formData.append('file', file);
+
+    try {
+        const response = await fetch('/venues/import', {
+            method: 'POST',
+            body: formData
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || `HTTP error! status: ${response.status}`);
+        }
+
+        let message = `Importovaných adries: ${data.imported}`;
+        if (data.skippedRows && data.skippedRows.length > 0) {
+            message += `, preskočené riadky: ${data.skippedRows.join(', ')}`;
+        }
+        showStatus(message + '. Adresy sa doplnia pri ďalšom načítaní kôl.', 'success');
+    } catch (error) {
+        console.error('[VENUES] ✗ Error importing venues:', error);
+        showStatus('Chyba pri importe adries: ' + error.message, 'error');
+    } finally {
+        fileInput.value = '';
+    }
+}
+
+// Whether fully played rounds should be loaded as well
+function includePlayedRounds() {
+    return document.getElementById('includePlayedRounds')?.checked || false;
+}
+
+// Escape text for use inside injected HTML
+function escapeHtml(text) {
+    return String(text)
+        .replace(/&/g, '&amp;')
+        .replace(/</g, '&lt;')
+        .replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;');
+}
+
+// Render the tournament metadata from the chess-results export header
+function renderTournamentInfo() {
+    if (!currentTournament || !currentTournament.title) {
+        return '';
+    }
+    const details = [
+        currentTournament.organizer,
+        currentTournament.location,
+        currentTournament.startDate && `${currentTournament.startDate} – ${currentTournament.endDate}`
+    ].filter(Boolean).map(escapeHtml).join(' · ');
+
+    return `
+        <div class="-mt-4 mb-6 text-sm text-gray-600">
+            <span class="font-medium text-gray-700">${escapeHtml(currentTournament.title)}</span>
+            ${details ? `<span> · ${details}</span>` : ''}
+        </div>
+    `;
+}
+
+// Mention the played rounds that were left out of the editor
+function renderPlayedRoundsNote() {
+    if (!playedRounds) {
+        return '';
+    }
+    return `
+        <p class="mb-6 text-sm text-gray-500">
+            Odohrané kolá (${playedRounds}) sú skryté. Zobrazíte ich voľbou „Zobraziť aj odohrané kolá“ a opätovným načítaním.
+        </p>
+    `;
+}
+
+// Status badge shown next to the round number
+function renderRoundStatus(round) {
+    if (round.status === 'played') {
+        return '<span class="ml-2 px-2 py-0.5 text-xs rounded bg-gray-200 text-gray-700">odohrané</span>';
+    }
+    if (round.status === 'partial') {
+        return '<span class="ml-2 px-2 py-0.5 text-xs rounded bg-yellow-100 text-yellow-800">čiastočne odohrané</span>';
+    }
+    return '';
+}
+
+// Render the rows the parser skipped, so the officer knows the schedule may be incomplete
+function renderParseWarnings() {
+    if (!currentWarnings.length) {
+        return '';
+    }
+
+    const items = currentWarnings.map(warning => `
+        <li>
+            <span class="font-medium">${escapeHtml(warning.sheet)}, riadok ${warning.row}:</span>
+            ${escapeHtml(warning.message)}
+            <span class="text-yellow-700 font-mono text-xs">[${escapeHtml((warning.content || []).join(' | '))}]</span>
+        </li>
+    `).join('');
+
+    return `
+        <div id="parseWarnings" class="mb-8 p-4 bg-yellow-50 border border-yellow-300 rounded-lg">
+            <h3 class="text-lg font-medium text-yellow-800 mb-2">Rozpis môže byť neúplný (${currentWarnings.length})</h3>
+            <p class="text-sm text-yellow-800 mb-2">Nasledujúce riadky z chess-results sa nepodarilo spracovať:</p>
+            <ul class="list-disc list-inside text-sm text-yellow-900 space-y-1">${items}</ul>
+        </div>
+    `;
+}
+
+// Slovak labels of the schedule change types
+const CHANGE_LABELS = {
+    added: 'Nový zápas',
+    removed: 'Zrušený zápas',
+    rescheduled: 'Zmena termínu',
+    relocated: 'Zmena miesta',
+    decided: 'Známi súperi'
+};
+
+// Render the differences between the current schedule and the one the delegations were issued for
+function renderScheduleChanges() {
+    if (!currentChanges || !currentChanges.changes || currentChanges.changes.length === 0) {
+        return '';
+    }
+
+    const items = currentChanges.changes.map(change => {
+        const before = change.before ? `${escapeHtml(change.before.dateTime)} ${escapeHtml(change.before.address)}` : '';
+        const after = change.after ? `${escapeHtml(change.after.dateTime)} ${escapeHtml(change.after.address)}` : '';
+        const arbiters = (change.delegations || [])
+            .map(d => escapeHtml(`${d.arbiter.FirstName} ${d.arbiter.LastName}`))
+            .join(', ');
+        return `
+            <li>
+                <span class="font-medium">${(change.kinds || [change.type]).map(kind => CHANGE_LABELS[kind] || escapeHtml(kind)).join(', ')}:</span>
+                ${change.round}. kolo, ${escapeHtml(change.homeTeam)} – ${escapeHtml(change.guestTeam)}
+                ${before && after ? `<span class="text-gray-600">(${before} → ${after})</span>` : ''}
+                ${arbiters ? `<span class="text-red-700">– delegovaný: ${arbiters}</span>` : ''}
+            </li>
+        `;
+    }).join('');
+
+    return `
+        <div id="scheduleChanges" class="mb-8 p-4 bg-red-50 border border-red-300 rounded-lg">
+            <h3 class="text-lg font-medium text-red-800 mb-2">Zmeny v rozpise od vydania delegácií (${currentChanges.changes.length})</h3>
+            <p class="text-sm text-red-800 mb-2">Dotknuté delegácie: ${currentChanges.affectedDelegations}</p>
+            <ul class="list-disc list-inside text-sm text-red-900 space-y-1">${items}</ul>
+            <button type="button"
+                onclick="acceptScheduleChanges()"
+                class="mt-3 px-3 py-1 text-sm border border-red-400 rounded hover:bg-red-100"
+            >Potvrdiť aktuálny rozpis</button>
+        </div>
+    `;
+}
+
+// Make the current schedule the planned one, so the changes are not reported again
+async function acceptScheduleChanges() {
+    if (!currentLeague) {
+        return;
+    }
+    try {
+        const response = await fetch('/schedule-changes/accept', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ leagueId: parseInt(currentLeague.leagueId) })
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || `HTTP error! status: ${response.status}`);
+        }
+        currentChanges = null;
+        document.getElementById('scheduleChanges')?.remove();
+        showStatus('Aktuálny rozpis bol potvrdený', 'success');
+    } catch (error) {
+        showStatus('Chyba pri potvrdení rozpisu: ' + error.message, 'error');
+    }
+}
+
 // Display the rounds editor interface
 function displayRoundsEditor() {
     const roundsContainer = document.getElementById('roundsEditor');
@@ -122,6 +400,8 @@ function displayRoundsEditor() {
     let html = `
         <div class="mx-auto bg-white rounded-lg shadow-md p-6">
             <h2 class="text-2xl font-semibold text-gray-700 mb-6">Uprav Kolá</h2>
+            ${renderTournamentInfo()}
+            ${renderPlayedRoundsNote()}
             
             <!-- Global Fields -->
             <div class="mb-8 p-4 bg-gray-50 rounded-lg">
@@ -149,6 +429,10 @@ function displayRoundsEditor() {
                 </div>
             </div>
 
+            ${renderParseWarnings()}
+            ${renderScheduleChanges()}
+            ${renderFixtureForm()}
+
             <!-- Rounds List -->
             <div class="space-y-6">
     `;
@@ -157,7 +441,7 @@ function displayRoundsEditor() {
         html += `
             <div id="round_${roundIndex}" class="border border-gray-200 rounded-lg p-4">
                 <div class="flex items-center justify-between mb-4">
-                    <h4 class="text-lg font-medium text-gray-700">Kolo č. ${round.number}</h4>
+                    <h4 class="text-lg font-medium text-gray-700">Kolo č. ${round.number}${renderRoundStatus(round)}</h4>
                     <button type="button"
                         id="round_${roundIndex}_visibility_btn"
                         onclick="toggleRoundVisibility(${roundIndex})"
@@ -166,20 +450,29 @@ function displayRoundsEditor() {
                     >${EYE_OPEN_SVG}</button>
                 </div>
 
+                ${renderVenueGroups(round)}
+
                 <div id="round_${roundIndex}_matches_container" class="space-y-3">
         `;
 
         // Add each match in the round
         round.matches.forEach((match, matchIndex) => {
             html += `
-                <div id="round_${roundIndex}_match_${matchIndex}" class="p-3 bg-gray-50 rounded border">
-                    <div class="flex justify-end mb-1">
+                <div id="round_${roundIndex}_match_${matchIndex}" data-match-id="${escapeHtml(match.id || '')}" data-excluded="${match.played ? 'true' : 'false'}" class="p-3 bg-gray-50 rounded border ${match.played ? 'opacity-40' : ''}">
+                    <div class="flex justify-end items-center gap-3 mb-1">
+                        ${match.played ? `<span class="mr-auto text-xs text-gray-600" title="Zápas je odohraný, delegácia sa negeneruje">Výsledok ${escapeHtml(match.homeResult)} : ${escapeHtml(match.guestResult)}</span>` : ''}
+                        ${match.placeholder ? `<span class="mr-auto text-xs text-purple-700" title="Súperi sa doplnia z chess-results po aktualizácii pavúka">Súperi zatiaľ nie sú známi</span>` : ''}
+                        <button type="button"
+                            onclick="previewMatchDelegation(${roundIndex}, ${matchIndex})"
+                            class="text-xs text-blue-600 hover:text-blue-800 underline"
+                            title="Otvoriť náhľad delegačného listu"
+                        >Náhľad</button>
                         <button type="button"
                             id="round_${roundIndex}_match_${matchIndex}_visibility_btn"
                             onclick="toggleMatchVisibility(${roundIndex}, ${matchIndex})"
                             class="text-gray-400 hover:text-gray-600"
                             title="Vylúčiť/zahrnúť zápas"
-                        >${EYE_OPEN_SVG}</button>
+                        >${match.played ? EYE_CLOSED_SVG : EYE_OPEN_SVG}</button>
                     </div>
                     <div class="grid grid-cols-1 md:grid-cols-4 gap-3 mb-3">
                         <div>
@@ -206,8 +499,14 @@ function displayRoundsEditor() {
                                 type="text" 
                                 id="round_${roundIndex}_match_${matchIndex}_datetime" 
                                 value="${match.dateTime}"
-                                class="w-full px-2 py-1 text-sm border border-gray-300 rounded focus:outline-none focus:ring-1 focus:ring-blue-500"
+                                placeholder="Chýba dátum a čas"
+                                class="w-full px-2 py-1 text-sm border ${match.dateTimeError ? 'border-red-500' : 'border-gray-300'} rounded focus:outline-none focus:ring-1 focus:ring-blue-500"
                             />
+                            ${match.dateTimeError ? `<p class="text-xs text-red-600 mt-1">${escapeHtml(match.dateTimeError)}</p>` : ''}
+                            <label class="flex items-center gap-1 text-xs text-gray-600 mt-1" title="Vytlačiť text tak, ako je zadaný (napr. podľa dohody)">
+                                <input type="checkbox" id="round_${roundIndex}_match_${matchIndex}_free_datetime" />
+                                Voľný text
+                            </label>
                         </div>
                         <div>
                             <label class="block text-xs font-medium text-gray-600 mb-1">Adresa hracej miestnosti</label>
@@ -289,6 +588,21 @@ function displayRoundsEditor() {
                                 </div>
                             </div>
                         </div>
+
+                        <!-- Further arbiters of the match (chief arbiter and deputy) -->
+                        <div id="round_${roundIndex}_match_${matchIndex}_officials" class="mt-2 space-y-1"></div>
+                        <div class="flex items-center gap-2 mt-1">
+                            <button
+                                type="button"
+                                onclick="addMatchOfficial(${roundIndex}, ${matchIndex})"
+                                class="text-xs text-blue-600 hover:text-blue-800 underline"
+                            >+ Ďalší rozhodca</button>
+                            <select
+                                id="round_${roundIndex}_match_${matchIndex}_arbiter_role"
+                                class="hidden px-1 py-0.5 text-xs border border-gray-300 rounded"
+                                title="Funkcia delegovaného rozhodcu"
+                            >${renderRoleOptions('chief_arbiter')}</select>
+                        </div>
                     </div>
                 </div>
             `;
@@ -301,7 +615,16 @@ function displayRoundsEditor() {
     });
 
     html += `
-        <div class="flex space-x-4 justify-end">
+        <div class="flex space-x-4 justify-end items-center">
+            <label for="zipLayout" class="text-sm font-medium text-gray-700">Usporiadanie ZIP:</label>
+            <select
+                id="zipLayout"
+                class="px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500"
+            >
+                <option value="flat">Všetky spolu</option>
+                <option value="round">Podľa kôl</option>
+                <option value="arbiter">Podľa rozhodcov</option>
+            </select>
             <button 
                 id="prepareDelegationBtn"
                 onclick="prepareDelegationData()"
@@ -323,6 +646,7 @@ function displayRoundsEditor() {
     
     // Populate arbiter dropdowns for all matches
     populateMatchArbiterDropdowns();
+    loadFixtures();
 }
 
 // Save rounds data
@@ -475,12 +799,26 @@ async function populateMatchArbiterDropdowns() {
                     }
                 });
             });
+
+            restoreArbiterAssignments();
         }
     } catch (error) {
         console.error('Error loading arbiters for match dropdowns:', error);
     }
 }
 
+// Re-apply arbiters chosen before the schedule was reloaded, matched by match ID
+function restoreArbiterAssignments() {
+    currentRounds.forEach((round, roundIndex) => {
+        round.matches.forEach((match, matchIndex) => {
+            const arbiter = match.id && arbiterAssignments[match.id];
+            if (arbiter) {
+                selectArbiter(roundIndex, matchIndex, arbiter);
+            }
+        });
+    });
+}
+
 // Populate arbiter dropdown with given arbiters list
 function populateArbiterDropdown(roundIndex, matchIndex, arbiters) {
     const dropdownElement = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_dropdown`);
@@ -571,6 +909,12 @@ function selectArbiter(roundIndex, matchIndex, arbiter) {
     
     // Store selected arbiter ID for later use (using PlayerId as official Slovak chess federation ID)
     searchInput.setAttribute('data-arbiter-id', arbiter.PlayerId);
+
+    // Remember the assignment by match ID, the indexes change when the schedule is reloaded
+    const matchId = currentRounds[roundIndex]?.matches[matchIndex]?.id;
+    if (matchId) {
+        arbiterAssignments[matchId] = arbiter;
+    }
     
     // Show arbiter details
     showArbiterDetails(roundIndex, matchIndex, arbiter);
@@ -617,20 +961,228 @@ async function onMatchArbiterSelected(roundIndex, matchIndex) {
     }
 }
 
-// Prepare PDFData array from current rounds data
-function preparePDFDataArray() {
+// Render the role options of a role select
+function renderRoleOptions(selectedRole) {
+    return ARBITER_ROLES
+        .map(role => `<option value="${role.value}" ${role.value === selectedRole ? 'selected' : ''}>${role.label}</option>`)
+        .join('');
+}
+
+// Add a further arbiter to a match; the first arbiter then gets a role as well (chief arbiter by default)
+function addMatchOfficial(roundIndex, matchIndex) {
+    const arbiters = window.allArbiters || [];
+    if (arbiters.length === 0) {
+        showStatus('Najprv načítajte rozhodcov z chess.sk', 'error');
+        return;
+    }
+
+    const container = document.getElementById(`round_${roundIndex}_match_${matchIndex}_officials`);
+    document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_role`).classList.remove('hidden');
+
+    const defaultRole = container.children.length === 0 ? 'deputy_arbiter' : 'arbiter';
+    const arbiterOptions = [...arbiters]
+        .sort((a, b) => `${a.LastName} ${a.FirstName}`.toLowerCase().localeCompare(`${b.LastName} ${b.FirstName}`.toLowerCase()))
+        .map(arbiter => `<option value="${arbiter.ArbiterId}">${arbiter.LastName} ${arbiter.FirstName} (${arbiter.PlayerId})</option>`)
+        .join('');
+
+    const row = document.createElement('div');
+    row.className = 'flex items-center gap-2';
+    row.innerHTML = `
+        <select class="match-official-arbiter flex-1 px-2 py-1 text-sm border border-gray-300 rounded">
+            <option value="">Vyberte rozhodcu</option>
+            ${arbiterOptions}
+        </select>
+        <select class="match-official-role px-1 py-1 text-xs border border-gray-300 rounded">${renderRoleOptions(defaultRole)}</select>
+        <button type="button" onclick="removeMatchOfficial(this, ${roundIndex}, ${matchIndex})"
+            class="text-xs text-red-600 hover:text-red-800">✕</button>
+    `;
+    container.appendChild(row);
+}
+
+// Remove a further arbiter; without further arbiters the match is delegated to a single arbiter again
+function removeMatchOfficial(button, roundIndex, matchIndex) {
+    button.parentElement.remove();
+    const container = document.getElementById(`round_${roundIndex}_match_${matchIndex}_officials`);
+    if (container.children.length === 0) {
+        document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_role`).classList.add('hidden');
+    }
+}
+
+// Collect the further arbiters of a match as {arbiter, role} officials
+function collectMatchOfficials(roundIndex, matchIndex) {
+    const officials = [];
+    document.querySelectorAll(`#round_${roundIndex}_match_${matchIndex}_officials > div`).forEach(row => {
+        const arbiterId = row.querySelector('.match-official-arbiter').value;
+        const arbiter = (window.allArbiters || []).find(a => String(a.ArbiterId) === arbiterId);
+        if (!arbiter) return;
+        officials.push({
+            arbiter: {
+                firstName: arbiter.FirstName,
+                lastName: arbiter.LastName,
+                playerId: arbiter.PlayerId
+            },
+            role: row.querySelector('.match-official-role').value
+        });
+    });
+    return officials;
+}
+
+// Build PDFData for a single match from the current form values (including any user edits)
+function buildMatchPDFData(roundIndex, matchIndex) {
     const leagueSelect = document.getElementById('leagueSelect');
-    
+    const round = currentRounds[roundIndex];
+    const match = round.matches[matchIndex];
+
     const globalDirectorInfo = document.getElementById('globalDirectorInfo')?.value;
     const globalContactPerson = document.getElementById('globalContactPerson')?.value || '';
-    
+
     // Get league name from the selected option
     const selectedLeagueOption = leagueSelect.options[leagueSelect.selectedIndex];
     const leagueName = selectedLeagueOption ? selectedLeagueOption.textContent.split(' (')[0] : '';
     const leagueYear = selectedLeagueOption ? selectedLeagueOption.textContent.match(/\((.+?)\)/)?.[1] || '' : '';
+
+    // Get current form data (including any user edits)
+    const homeTeam = document.getElementById(`round_${roundIndex}_match_${matchIndex}_home`)?.value || match.homeTeam;
+    const guestTeam = document.getElementById(`round_${roundIndex}_match_${matchIndex}_guest`)?.value || match.guestTeam;
+    const dateTime = document.getElementById(`round_${roundIndex}_match_${matchIndex}_datetime`)?.value || match.dateTime;
+    const address = document.getElementById(`round_${roundIndex}_match_${matchIndex}_address`)?.value || match.address;
     
+    // Get arbiter info — check manual mode first
+    const manualSection = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_section`);
+    const isManualMode = manualSection && !manualSection.classList.contains('hidden');
+
+    let arbiterFirstName = '';
+    let arbiterLastName = '';
+    let arbiterId = '';
+
+    if (isManualMode) {
+        arbiterFirstName = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_firstname`)?.value || '';
+        arbiterLastName = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_lastname`)?.value || '';
+        arbiterId = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_id`)?.value || '';
+    } else {
+        const arbiterSearchInput = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_search`);
+        const selectedArbiterId = arbiterSearchInput ? arbiterSearchInput.getAttribute('data-arbiter-id') : '';
+        const arbiterDetails = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_details`);
+        let arbiterName = '';
+
+        if (arbiterDetails && arbiterDetails.textContent) {
+            const detailsText = arbiterDetails.textContent;
+            const nameMatch = detailsText.match(/<strong>(.+?)<\/strong>/);
+            if (nameMatch) {
+                arbiterName = nameMatch[1];
+                arbiterId = selectedArbiterId;
+            }
+        }
+
+        if (!arbiterName && arbiterSearchInput && arbiterSearchInput.value) {
+            const arbiterMatch = arbiterSearchInput.value.match(/^(.+?) \((.+?)\)(?: - (.+))?$/);
+            if (arbiterMatch) {
+                arbiterName = arbiterMatch[1];
+                arbiterId = selectedArbiterId;
+            }
+        }
+
+        // arbiterName is stored as "LastName FirstName"
+        arbiterFirstName = arbiterName.split(' ')[0] || '';
+        arbiterLastName = arbiterName.split(' ').slice(1).join(' ') || '';
+    }
+
+    const arbiter = {
+        firstName: arbiterFirstName,
+        lastName: arbiterLastName,
+        playerId: arbiterId,
+        clubName: '' // just because of the updated ArbiterInfo in backend it wont run without this line :D
+    };
+
+    // With further arbiters the match is delegated to all of them, each with a role
+    const furtherOfficials = collectMatchOfficials(roundIndex, matchIndex);
+    const role = furtherOfficials.length > 0
+        ? document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_role`).value
+        : '';
+    const officials = furtherOfficials.length > 0
+        ? [{ arbiter: arbiter, role: role }, ...furtherOfficials]
+        : [];
+
+    return {
+        league: {
+            name: leagueName,
+            year: leagueYear
+        },
+        director: {
+            contact: globalDirectorInfo
+        },
+        arbiter: arbiter,
+        role: role,
+        officials: officials,
+        match: {
+            matchId: match.id || '',
+            round: round.number,
+            homeTeam: homeTeam,
+            guestTeam: guestTeam,
+            dateTime: dateTime,
+            address: address,
+            freeDateTime: document.getElementById(`round_${roundIndex}_match_${matchIndex}_free_datetime`)?.checked || false
+        },
+        contactPerson: globalContactPerson
+    };
+}
+
+// Offer one delegation for matches played in the same hall at the same time (e.g. centralized rounds)
+function renderVenueGroups(round) {
+    return venueGroups
+        .map((group, groupIndex) => {
+            if (group.round !== round.number) return '';
+            const matches = group.matchIds
+                .map(id => findMatchPosition(id))
+                .filter(Boolean)
+                .map(({ roundIndex, matchIndex }) => currentRounds[roundIndex].matches[matchIndex])
+                .map(match => `${escapeHtml(match.homeTeam)} – ${escapeHtml(match.guestTeam)}`)
+                .join(', ');
+            return `
+                <label class="flex items-start gap-2 mb-3 p-2 text-sm bg-yellow-50 border border-yellow-200 rounded">
+                    <input type="checkbox" id="venue_group_${groupIndex}" class="mt-1" />
+                    <span>
+                        Jedna delegácia pre ${group.matchIds.length} zápasy v miestnosti ${escapeHtml(group.address)} (${escapeHtml(group.dateTime)}): ${matches}.
+                        <span class="text-gray-500">Rozhodcu vyberte pri prvom zápase.</span>
+                    </span>
+                </label>
+            `;
+        })
+        .join('');
+}
+
+// Find the round and match index of a match by its ID
+function findMatchPosition(matchId) {
+    for (let roundIndex = 0; roundIndex < currentRounds.length; roundIndex++) {
+        const matchIndex = currentRounds[roundIndex].matches.findIndex(match => match.id === matchId);
+        if (matchIndex >= 0) {
+            return { roundIndex, matchIndex };
+        }
+    }
+    return null;
+}
+
+// Map "roundIndex_matchIndex" to the venue group index for every group selected for one delegation
+function selectedVenueGroupPositions() {
+    const positions = {};
+    venueGroups.forEach((group, groupIndex) => {
+        if (!document.getElementById(`venue_group_${groupIndex}`)?.checked) return;
+        group.matchIds.forEach(id => {
+            const position = findMatchPosition(id);
+            if (position) {
+                positions[`${position.roundIndex}_${position.matchIndex}`] = groupIndex;
+            }
+        });
+    });
+    return positions;
+}
+
+// Prepare PDFData array from current rounds data
+function preparePDFDataArray() {
     const pdfDataArray = [];
-    
+    const groupPositions = selectedVenueGroupPositions();
+    const groupDelegations = {};
+
     // Create PDFData for each match
     currentRounds.forEach((round, roundIndex) => {
         const roundEl = document.getElementById(`round_${roundIndex}`);
@@ -639,84 +1191,81 @@ function preparePDFDataArray() {
         round.matches.forEach((match, matchIndex) => {
             const matchEl = document.getElementById(`round_${roundIndex}_match_${matchIndex}`);
             if (matchEl?.dataset.excluded === 'true') return;
-            // Get current form data (including any user edits)
-            const homeTeam = document.getElementById(`round_${roundIndex}_match_${matchIndex}_home`)?.value || match.homeTeam;
-            const guestTeam = document.getElementById(`round_${roundIndex}_match_${matchIndex}_guest`)?.value || match.guestTeam;
-            const dateTime = document.getElementById(`round_${roundIndex}_match_${matchIndex}_datetime`)?.value || match.dateTime;
-            const address = document.getElementById(`round_${roundIndex}_match_${matchIndex}_address`)?.value || match.address;
-            
-            // Get arbiter info — check manual mode first
-            const manualSection = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_section`);
-            const isManualMode = manualSection && !manualSection.classList.contains('hidden');
-
-            let arbiterFirstName = '';
-            let arbiterLastName = '';
-            let arbiterId = '';
-
-            if (isManualMode) {
-                arbiterFirstName = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_firstname`)?.value || '';
-                arbiterLastName = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_lastname`)?.value || '';
-                arbiterId = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_manual_id`)?.value || '';
-            } else {
-                const arbiterSearchInput = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_search`);
-                const selectedArbiterId = arbiterSearchInput ? arbiterSearchInput.getAttribute('data-arbiter-id') : '';
-                const arbiterDetails = document.getElementById(`round_${roundIndex}_match_${matchIndex}_arbiter_details`);
-                let arbiterName = '';
-
-                if (arbiterDetails && arbiterDetails.textContent) {
-                    const detailsText = arbiterDetails.textContent;
-                    const nameMatch = detailsText.match(/<strong>(.+?)<\/strong>/);
-                    if (nameMatch) {
-                        arbiterName = nameMatch[1];
-                        arbiterId = selectedArbiterId;
-                    }
-                }
-
-                if (!arbiterName && arbiterSearchInput && arbiterSearchInput.value) {
-                    const arbiterMatch = arbiterSearchInput.value.match(/^(.+?) \((.+?)\)(?: - (.+))?$/);
-                    if (arbiterMatch) {
-                        arbiterName = arbiterMatch[1];
-                        arbiterId = selectedArbiterId;
-                    }
-                }
 
-                // arbiterName is stored as "LastName FirstName"
-                arbiterFirstName = arbiterName.split(' ')[0] || '';
-                arbiterLastName = arbiterName.split(' ').slice(1).join(' ') || '';
+            const pdfData = buildMatchPDFData(roundIndex, matchIndex);
+            const groupIndex = groupPositions[`${roundIndex}_${matchIndex}`];
+            if (groupIndex === undefined) {
+                pdfDataArray.push(pdfData);
+                return;
             }
 
-            const pdfData = {
-                league: {
-                    name: leagueName,
-                    year: leagueYear
-                },
-                director: {
-                    contact: globalDirectorInfo
-                },
-                arbiter: {
-                    firstName: arbiterFirstName,
-                    lastName: arbiterLastName,
-                    playerId: arbiterId,
-                    clubName: '' // just because of the updated ArbiterInfo in backend it wont run without this line :D
-                },
-                match: {
-                    homeTeam: homeTeam,
-                    guestTeam: guestTeam,
-                    dateTime: dateTime,
-                    address: address
-                },
-                contactPerson: globalContactPerson
-            };
-            
-            pdfDataArray.push(pdfData);
+            // The first match of a venue group carries the delegation, the others are listed in it
+            const groupDelegation = groupDelegations[groupIndex];
+            if (groupDelegation) {
+                groupDelegation.matches.push(pdfData.match);
+            } else {
+                pdfData.matches = [pdfData.match];
+                groupDelegations[groupIndex] = pdfData;
+                pdfDataArray.push(pdfData);
+            }
         });
     });
-    
+
+    // A group with a single included match is an ordinary delegation
+    Object.values(groupDelegations).forEach(pdfData => {
+        if (pdfData.matches.length < 2) {
+            pdfData.matches = [];
+        }
+    });
+
     return pdfDataArray;
 }
 
+// Open a watermarked preview of a single delegation in a new tab
+async function previewMatchDelegation(roundIndex, matchIndex) {
+    // Open the tab right away, browsers block pop-ups opened after an await
+    const previewWindow = window.open('', '_blank');
+
+    try {
+        const response = await fetch('/preview-delegation', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify(buildMatchPDFData(roundIndex, matchIndex))
+        });
+
+        if (!response.ok) {
+            let errorMessage = `Server error: ${response.status} ${response.statusText}`;
+            try {
+                const errorData = await response.json();
+                errorMessage = `Server error: ${errorData.error || 'Unknown error'}`;
+                // Invalid delegations name the reason, e.g. a date and time that cannot be read
+                if (response.status === 422 && errorData.invalidItems?.length) {
+                    errorMessage = errorData.invalidItems.map(item => item.error).join('; ');
+                }
+            } catch (jsonError) {
+                console.warn('Could not parse error response as JSON:', jsonError);
+            }
+            throw new Error(errorMessage);
+        }
+
+        const blob = await response.blob();
+        const url = window.URL.createObjectURL(blob);
+        if (previewWindow) {
+            previewWindow.location.href = url;
+        } else {
+            window.open(url, '_blank');
+        }
+    } catch (error) {
+        console.error('Error generating preview:', error);
+        if (previewWindow) previewWindow.close();
+        showStatus('Chyba pri generovaní náhľadu: ' + error.message, 'error');
+    }
+}
+
 // Prepare delegation data and send to backend
-async function prepareDelegationData() {
+async function prepareDelegationData(allowConflicts = false) {
     const leagueSelect = document.getElementById('leagueSelect');
     const roundsStatus = document.getElementById('roundsStatus');
     
@@ -750,7 +1299,15 @@ async function prepareDelegationData() {
         roundsStatus.innerHTML = '<span class="text-blue-600">⏳ Generating PDFs and creating zip file...</span>';
         
         // Send to backend
-        const response = await fetch('/delegate-arbiters', {
+        const zipLayout = document.getElementById('zipLayout')?.value || 'flat';
+        const params = new URLSearchParams({ layout: zipLayout });
+        if (currentLeague && currentLeague.leagueId) {
+            params.set('leagueId', currentLeague.leagueId);
+        }
+        if (allowConflicts) {
+            params.set('allowConflicts', 'true');
+        }
+        const response = await fetch(`/delegate-arbiters?${params}`, {
             method: 'POST',
             headers: {
                 'Content-Type': 'application/json',
@@ -758,6 +1315,35 @@ async function prepareDelegationData() {
             body: JSON.stringify(pdfDataArray)
         });
         
+        // Arbiters who play in the league need an explicit confirmation
+        if (response.status === 409) {
+            const conflictData = await response.json();
+            const conflicts = conflictData.conflicts || [];
+            const describe = conflict => `Kolo ${conflict.round}: ${conflict.homeTeam} - ${conflict.guestTeam}\n  ${conflict.message}`;
+            let summary = conflicts.map(describe).join('\n');
+            const warnings = conflictData.warnings || [];
+            if (warnings.length > 0) {
+                summary += `\n\nUpozornenia (člen klubu jedného z družstiev):\n${warnings.map(describe).join('\n')}`;
+            }
+            if (confirm(`Niektorí rozhodcovia hrajú v tejto súťaži:\n\n${summary}\n\nGenerovať delegačné listy aj tak?`)) {
+                return prepareDelegationData(true);
+            }
+            roundsStatus.innerHTML = `<span class="text-red-600">✗ Konflikty rozhodcov (${conflicts.length}), delegácie neboli vygenerované</span>`;
+            return;
+        }
+
+        // Delegations that cannot be generated are listed, nothing is generated until they are fixed
+        if (response.status === 422) {
+            const invalidData = await response.json();
+            const items = (invalidData.invalidItems || [])
+                .map(item => `<li>${escapeHtml(item.homeTeam)} – ${escapeHtml(item.guestTeam)}: ${escapeHtml(item.error)}</li>`)
+                .join('');
+            roundsStatus.innerHTML = `
+                <span class="text-red-600">✗ Niektoré delegácie nie je možné vygenerovať. Opravte dátum a čas, alebo ho označte ako voľný text:</span>
+                <ul class="list-disc list-inside text-sm text-red-700">${items}</ul>`;
+            return;
+        }
+
         if (!response.ok) {
             // Handle error responses
             let errorMessage = `Server error: ${response.status} ${response.statusText}`;
@@ -797,10 +1383,14 @@ async function prepareDelegationData() {
             document.body.removeChild(a);
             window.URL.revokeObjectURL(url);
             
+            // The server generates the delegations even when the rosters for the conflict check are unavailable
+            const conflictCheckNote = response.headers.get('X-Conflict-Check') === 'skipped'
+                ? '<br><span class="text-sm text-orange-600">⚠ Súpisky družstiev sa nepodarilo načítať, konflikty rozhodcov neboli skontrolované</span>'
+                : '';
             roundsStatus.innerHTML = `
                 <span class="text-green-600">✓ PDFs generated and zip file downloaded successfully!</span><br>
                 <span class="text-sm text-gray-600">Count: ${pdfDataArray.length} items</span><br>
-                <span class="text-sm text-gray-600">File: ${filename}</span>
+                <span class="text-sm text-gray-600">File: ${filename}</span>${conflictCheckNote}
             `;
         } else {
             // Fallback for JSON response (shouldn't happen with current backend)
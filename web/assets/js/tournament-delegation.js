@@ -0,0 +1,147 @@
+let tournamentOfficialCount = 0;
+
+// Load the arbiters once, reusing the list loaded for the rounds editor when there is one
+async function getTournamentArbiters() {
+    if (window.allArbiters && window.allArbiters.length > 0) {
+        return window.allArbiters;
+    }
+    const response = await fetch('/arbiters');
+    const data = await response.json();
+    window.allArbiters = data.arbiters || [];
+    return window.allArbiters;
+}
+
+// Add a row with an arbiter and role selection; the first row defaults to chief arbiter
+async function addTournamentOfficial() {
+    const status = document.getElementById('tournamentStatus');
+    let arbiters;
+    try {
+        arbiters = await getTournamentArbiters();
+    } catch (error) {
+        console.error('[TOURNAMENT] ✗ Error loading arbiters:', error);
+        status.innerHTML = '<span class="text-red-600">✗ Najprv načítajte dáta z chess.sk</span>';
+        return;
+    }
+    if (arbiters.length === 0) {
+        status.innerHTML = '<span class="text-red-600">✗ Najprv načítajte dáta z chess.sk</span>';
+        return;
+    }
+
+    const container = document.getElementById('tournamentOfficials');
+    const index = tournamentOfficialCount++;
+    const defaultRole = container.children.length === 0 ? 'chief_arbiter' : 'arbiter';
+
+    const sortedArbiters = [...arbiters].sort((a, b) =>
+        `${a.LastName} ${a.FirstName}`.toLowerCase().localeCompare(`${b.LastName} ${b.FirstName}`.toLowerCase())
+    );
+    const arbiterOptions = sortedArbiters
+        .map(arbiter => `<option value="${arbiter.ArbiterId}">${arbiter.LastName} ${arbiter.FirstName} (${arbiter.PlayerId})</option>`)
+        .join('');
+    const roleOptions = renderRoleOptions(defaultRole);
+
+    const row = document.createElement('div');
+    row.id = `tournament_official_${index}`;
+    row.className = 'flex items-center gap-3';
+    row.innerHTML = `
+        <select class="tournament-official-arbiter flex-1 px-3 py-2 border border-gray-300 rounded-md">
+            <option value="">Vyberte rozhodcu</option>
+            ${arbiterOptions}
+        </select>
+        <select class="tournament-official-role px-3 py-2 border border-gray-300 rounded-md">${roleOptions}</select>
+        <button type="button" onclick="document.getElementById('tournament_official_${index}').remove()"
+            class="px-2 py-1 text-sm text-red-600 border border-gray-300 rounded hover:bg-gray-100">Odstrániť</button>
+    `;
+    container.appendChild(row);
+    status.innerHTML = '';
+}
+
+// Collect the tournament form into the TournamentPDFData format
+function prepareTournamentData() {
+    const value = id => document.getElementById(id).value.trim();
+    // Date inputs give "2025-10-25"
+    const date = id => value(id).replaceAll('-', '/');
+
+    const officials = [];
+    document.querySelectorAll('#tournamentOfficials > div').forEach(row => {
+        const arbiterId = row.querySelector('.tournament-official-arbiter').value;
+        const arbiter = (window.allArbiters || []).find(a => String(a.ArbiterId) === arbiterId);
+        if (!arbiter) return;
+        officials.push({
+            arbiter: {
+                firstName: arbiter.FirstName,
+                lastName: arbiter.LastName,
+                playerId: arbiter.PlayerId
+            },
+            role: row.querySelector('.tournament-official-role').value
+        });
+    });
+
+    return {
+        tournament: {
+            name: value('tournamentName'),
+            year: value('tournamentSeason'),
+            startDate: date('tournamentStart'),
+            endDate: date('tournamentEnd'),
+            venue: value('tournamentVenue')
+        },
+        officials: officials,
+        director: {
+            contact: value('tournamentDirector')
+        },
+        contactPerson: value('tournamentContactPerson')
+    };
+}
+
+// Generate the tournament delegation and download the PDF
+async function generateTournamentDelegation() {
+    const status = document.getElementById('tournamentStatus');
+    const tournamentData = prepareTournamentData();
+
+    if (tournamentData.officials.length === 0) {
+        status.innerHTML = '<span class="text-red-600">✗ Vyberte aspoň jedného rozhodcu</span>';
+        return;
+    }
+
+    status.innerHTML = '<span class="text-blue-600">⏳ Generujem delegačný list...</span>';
+    try {
+        const response = await fetch('/delegate-tournament', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify(tournamentData)
+        });
+        if (!response.ok) {
+            let errorMessage = `HTTP error! status: ${response.status}`;
+            try {
+                const errorData = await response.json();
+                errorMessage = errorData.error || errorMessage;
+            } catch (jsonError) {
+                console.warn('Could not parse error response as JSON:', jsonError);
+            }
+            throw new Error(errorMessage);
+        }
+
+        const blob = await response.blob();
+        const url = window.URL.createObjectURL(blob);
+        let filename = 'delegacny_list_turnaja.pdf';
+        const contentDisposition = response.headers.get('content-disposition');
+        const filenameMatch = contentDisposition && contentDisposition.match(/filename="(.+)"/);
+        if (filenameMatch) {
+            filename = filenameMatch[1];
+        }
+
+        const a = document.createElement('a');
+        a.href = url;
+        a.download = filename;
+        document.body.appendChild(a);
+        a.click();
+        document.body.removeChild(a);
+        window.URL.revokeObjectURL(url);
+
+        status.innerHTML = `<span class="text-green-600">✓ Delegačný list stiahnutý (${filename})</span>`;
+    } catch (error) {
+        console.error('[TOURNAMENT] ✗ Error generating delegation:', error);
+        status.innerHTML = `<span class="text-red-600">✗ ${error.message}</span>`;
+    }
+}